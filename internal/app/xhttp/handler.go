@@ -1,9 +1,11 @@
 package xhttp
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 
 	"github.com/labstack/echo/v4"
 	"github.com/thecodingmachine/gotenberg/internal/app/xhttp/pkg/context"
@@ -16,6 +18,45 @@ import (
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xtime"
 )
 
+/*
+consoleMessagesHTTPHeader is the HTTP header
+sent to the webhook URL with the JavaScript console
+messages captured during the conversion.
+*/
+const consoleMessagesHTTPHeader string = "Gotenberg-Console-Messages"
+
+/*
+maxConsoleMessagesHTTPHeaderSize is the maximum
+size of the console messages HTTP header, as
+many servers reject the headers above 8 KB.
+*/
+const maxConsoleMessagesHTTPHeaderSize int = 4096
+
+/*
+consoleMessagesHeader returns the JSON array of
+given console messages. If it exceeds the maximum
+size of the HTTP header, the last messages are
+replaced with a message counting them.
+*/
+func consoleMessagesHeader(messages []string) (string, error) {
+	for n := len(messages); n >= 0; n-- {
+		kept := messages[:n:n]
+		if n < len(messages) {
+			kept = append(kept, fmt.Sprintf("%d more message(s) truncated", len(messages)-n))
+		}
+		b, err := json.Marshal(kept)
+		if err != nil {
+			return "", err
+		}
+		if len(b) <= maxConsoleMessagesHTTPHeaderSize {
+			return string(b), nil
+		}
+	}
+	// unreachable, as the counting
+	// message alone is small enough.
+	return "[]", nil
+}
+
 func pingEndpoint(config conf.Config) string {
 	return fmt.Sprintf("%s%s", config.RootPath(), "ping")
 }
@@ -119,6 +160,9 @@ func htmlHandler(c echo.Context) error {
 		logger := ctx.XLogger()
 		logger.DebugOp(op, "handling HTML request...")
 		r := ctx.MustResource()
+		if err := r.ExtractArchives(); err != nil {
+			return err
+		}
 		opts, err := chromePrinterOptions(r, ctx.Config())
 		if err != nil {
 			return err
@@ -127,7 +171,18 @@ func htmlHandler(c echo.Context) error {
 		if err != nil {
 			return err
 		}
-		p := printer.NewHTMLPrinter(logger, fpath, opts)
+		data, ok, err := resource.TemplateDataArg(r)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			p := printer.NewHTMLPrinter(logger, fpath, opts)
+			return convert(ctx, p)
+		}
+		p, err := printer.NewHTMLTemplatePrinter(logger, fpath, data, opts)
+		if err != nil {
+			return err
+		}
 		return convert(ctx, p)
 	}
 	if err := resolver(); err != nil {
@@ -150,6 +205,10 @@ func urlHandler(c echo.Context) error {
 			return err
 		}
 		opts.CustomHTTPHeaders = resource.RemoteURLCustomHTTPHeaders(r)
+		opts.Cookies, err = resource.CookiesArg(r)
+		if err != nil {
+			return err
+		}
 		if !r.HasArg(resource.RemoteURLArgKey) {
 			return xerror.Invalid(
 				op,
@@ -179,14 +238,22 @@ func markdownHandler(c echo.Context) error {
 		logger := ctx.XLogger()
 		logger.DebugOp(op, "handling Markdown request...")
 		r := ctx.MustResource()
-		opts, err := chromePrinterOptions(r, ctx.Config())
-		if err != nil {
+		if err := r.ExtractArchives(); err != nil {
 			return err
 		}
-		fpath, err := r.Fpath("index.html")
+		opts, err := markdownPrinterOptions(r, ctx.Config())
 		if err != nil {
 			return err
 		}
+		// without index.html, the Markdown
+		// printer uses a default document.
+		fpath := filepath.Join(r.DirPath(), "index.html")
+		if r.HasFile("index.html") {
+			fpath, err = r.Fpath("index.html")
+			if err != nil {
+				return err
+			}
+		}
 		p, err := printer.NewMarkdownPrinter(logger, fpath, opts)
 		if err != nil {
 			return err
@@ -212,23 +279,19 @@ func officeHandler(c echo.Context) error {
 		if err != nil {
 			return err
 		}
-		fpaths, err := r.Fpaths(
-			".txt",
-			".rtf",
-			".fodt",
-			".doc",
-			".docx",
-			".odt",
-			".xls",
-			".xlsx",
-			".ods",
-			".ppt",
-			".pptx",
-			".odp",
-		)
+		fpaths, err := r.Fpaths(printer.OfficeExtensions()...)
 		if err != nil {
 			return err
 		}
+		if err := printer.ValidateOfficeConversion(fpaths, opts.OutputFormat); err != nil {
+			return err
+		}
+		if err := printer.ValidateOfficeOutputOptions(opts); err != nil {
+			return err
+		}
+		if err := printer.ValidateOfficePassword(fpaths, opts.DocumentPassword); err != nil {
+			return err
+		}
 		p := printer.NewOfficePrinter(logger, fpaths, opts)
 		return convert(ctx, p)
 	}
@@ -244,20 +307,26 @@ func convert(ctx context.Context, p printer.Printer) error {
 		logger := ctx.XLogger()
 		r := ctx.MustResource()
 		baseFilename := xrand.Get()
-		filename := fmt.Sprintf("%s.pdf", baseFilename)
+		ext, contentType := ".pdf", "application/pdf"
+		// some printers may create
+		// another file than a PDF.
+		if reporter, ok := p.(printer.OutputReporter); ok {
+			ext, contentType = reporter.Output()
+		}
+		filename := fmt.Sprintf("%s%s", baseFilename, ext)
 		fpath := fmt.Sprintf("%s/%s", r.DirPath(), filename)
 		// if no webhook URL given, run conversion
-		// and directly return the resulting PDF file
+		// and directly return the resulting file
 		// or an error.
 		if !r.HasArg(resource.WebhookURLArgKey) {
 			logger.DebugOpf(op, "no '%s' found, converting synchronously", resource.WebhookURLArgKey)
-			return convertSync(ctx, p, filename, fpath)
+			return convertSync(ctx, p, filename, fpath, contentType)
 		}
 		// as a webhook URL has been given, we
 		// run the following lines in a goroutine so that
 		// it doesn't block.
 		logger.DebugOpf(op, "'%s' found, converting asynchronously", resource.WebhookURLArgKey)
-		return convertAsync(ctx, p, filename, fpath)
+		return convertAsync(ctx, p, filename, fpath, contentType)
 	}
 	if err := resolver(); err != nil {
 		return xerror.New(op, err)
@@ -265,7 +334,7 @@ func convert(ctx context.Context, p printer.Printer) error {
 	return nil
 }
 
-func convertSync(ctx context.Context, p printer.Printer, filename, fpath string) error {
+func convertSync(ctx context.Context, p printer.Printer, filename, fpath, contentType string) error {
 	const op = "xhttp.convertSync"
 	resolver := func() error {
 		logger := ctx.XLogger()
@@ -274,6 +343,7 @@ func convertSync(ctx context.Context, p printer.Printer, filename, fpath string)
 		if err := p.Print(fpath); err != nil {
 			return err
 		}
+		ctx.Response().Header().Set(echo.HeaderContentType, contentType)
 		if !r.HasArg(resource.ResultFilenameArgKey) {
 			logger.DebugOpf(
 				op,
@@ -306,7 +376,7 @@ func convertSync(ctx context.Context, p printer.Printer, filename, fpath string)
 	return nil
 }
 
-func convertAsync(ctx context.Context, p printer.Printer, filename, fpath string) error {
+func convertAsync(ctx context.Context, p printer.Printer, filename, fpath, contentType string) error {
 	const op = "xhttp.convertAsync"
 	logger := ctx.XLogger()
 	r := ctx.MustResource()
@@ -347,7 +417,20 @@ func convertAsync(ctx context.Context, p printer.Printer, filename, fpath string
 			logger.ErrorOp(xerror.Op(xerr), xerr)
 			return
 		}
-		req.Header.Set(echo.HeaderContentType, "application/pdf")
+		req.Header.Set(echo.HeaderContentType, contentType)
+		// set the JavaScript console messages (if any).
+		if reporter, ok := p.(printer.ConsoleReporter); ok {
+			consoleMessages := reporter.ConsoleMessages()
+			if len(consoleMessages) > 0 {
+				header, err := consoleMessagesHeader(consoleMessages)
+				if err != nil {
+					xerr := xerror.New(op, err)
+					logger.ErrorOp(xerror.Op(xerr), xerr)
+					return
+				}
+				req.Header.Set(consoleMessagesHTTPHeader, header)
+			}
+		}
 		// set custom headers (if any).
 		customHTTPHeaders := resource.WebhookURLCustomHTTPHeaders(r)
 		if len(customHTTPHeaders) > 0 {
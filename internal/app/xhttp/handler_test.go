@@ -1,11 +1,13 @@
 package xhttp
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/labstack/echo/v4"
@@ -66,6 +68,18 @@ func TestMergeHandler(t *testing.T) {
 	req = httptest.NewRequest(http.MethodPost, endpoint, body)
 	req.Header.Set(echo.HeaderContentType, contentType)
 	test.AssertStatusCode(t, http.StatusGatewayTimeout, srv, req)
+	// should return 400 as "stampPosition" form field
+	// value is invalid.
+	body, contentType = test.MergeMultipartForm(t, map[string]string{string(resource.StampPositionArgKey): "middle"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "stampFontSize" form field
+	// value is < 4.
+	body, contentType = test.MergeMultipartForm(t, map[string]string{string(resource.StampFontSizeArgKey): "1"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
 }
 
 func TestHTMLHandler(t *testing.T) {
@@ -77,6 +91,27 @@ func TestHTMLHandler(t *testing.T) {
 	req := httptest.NewRequest(http.MethodPost, endpoint, body)
 	req.Header.Set(echo.HeaderContentType, contentType)
 	test.AssertStatusCode(t, http.StatusOK, srv, req)
+	// should return 200 with an archive.
+	body, contentType = test.ArchiveMultipartForm(t, "site.zip", nil)
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusOK, srv, req)
+	// should return 200 with JSON data.
+	body, contentType = test.TemplateMultipartForm(t, nil)
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusOK, srv, req)
+	// should return 200 with a table of contents.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.TableOfContentsArgKey): "true"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusOK, srv, req)
+	// should return 200 with a header
+	// referring to an image and a stylesheet.
+	body, contentType = test.InlineMultipartForm(t, nil)
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusOK, srv, req)
 	// should return 405 as Method is wrong.
 	req = httptest.NewRequest(http.MethodGet, endpoint, nil)
 	test.AssertStatusCode(t, http.StatusMethodNotAllowed, srv, req)
@@ -221,6 +256,99 @@ func TestHTMLHandler(t *testing.T) {
 	req = httptest.NewRequest(http.MethodPost, endpoint, body)
 	req.Header.Set(echo.HeaderContentType, contentType)
 	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "emulatedMediaType" form field
+	// value is invalid.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.EmulatedMediaTypeArgKey): "foo"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "preferCSSPageSize" form field
+	// value is invalid.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.PreferCSSPageSizeArgKey): "not a boolean"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "failOnConsoleExceptions" form field
+	// value is invalid.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.FailOnConsoleExceptionsArgKey): "not a boolean"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "failOnHTTPStatusCodes" form field
+	// value is invalid.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.FailOnHTTPStatusCodesArgKey): "not a JSON array"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "failOnResourceLoadingFailed" form field
+	// value is invalid.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.FailOnResourceLoadingFailedArgKey): "not a boolean"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "viewportWidth" form field
+	// value is invalid.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.ViewportWidthArgKey): "-1"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "isMobile" form field
+	// value is invalid.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.IsMobileArgKey): "not a boolean"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "blockedURLPatterns" form field
+	// value is invalid.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.BlockedURLPatternsArgKey): "not a JSON array"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "disableJavaScript" form field
+	// value is invalid.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.DisableJavaScriptArgKey): "not a boolean"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "waitForSelector" form field
+	// cannot be used with "disableJavaScript".
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{
+		string(resource.DisableJavaScriptArgKey): "true",
+		string(resource.WaitForSelectorArgKey):   "#foo",
+	})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "generateDocumentOutline" form field
+	// value is invalid.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.GenerateDocumentOutlineArgKey): "not a boolean"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "tableOfContents" form field
+	// value is invalid.
+	body, contentType = test.HTMLMultipartForm(t, map[string]string{string(resource.TableOfContentsArgKey): "not a boolean"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as the archive has
+	// an entry outside of its directory.
+	body, contentType = test.ArchiveMultipartForm(t, "zip_slip.zip", nil)
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "data" form field
+	// value is invalid.
+	body, contentType = test.TemplateMultipartForm(t, map[string]string{string(resource.DataArgKey): "{foo"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as the template
+	// execution fails with "data" form field.
+	body, contentType = test.TemplateMultipartForm(t, map[string]string{string(resource.DataArgKey): `{"total": "foo"}`})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
 }
 
 func TestURLHandler(t *testing.T) {
@@ -376,6 +504,87 @@ func TestURLHandler(t *testing.T) {
 	req = httptest.NewRequest(http.MethodPost, endpoint, body)
 	req.Header.Set(echo.HeaderContentType, contentType)
 	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "emulatedMediaType" form field
+	// value is invalid.
+	body, contentType = test.URLMultipartForm(t, map[string]string{string(resource.EmulatedMediaTypeArgKey): "foo"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "preferCSSPageSize" form field
+	// value is invalid.
+	body, contentType = test.URLMultipartForm(t, map[string]string{string(resource.PreferCSSPageSizeArgKey): "not a boolean"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "failOnConsoleExceptions" form field
+	// value is invalid.
+	body, contentType = test.URLMultipartForm(t, map[string]string{string(resource.FailOnConsoleExceptionsArgKey): "not a boolean"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "failOnHTTPStatusCodes" form field
+	// value is invalid.
+	body, contentType = test.URLMultipartForm(t, map[string]string{string(resource.FailOnHTTPStatusCodesArgKey): "not a JSON array"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "failOnResourceLoadingFailed" form field
+	// value is invalid.
+	body, contentType = test.URLMultipartForm(t, map[string]string{string(resource.FailOnResourceLoadingFailedArgKey): "not a boolean"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "cookies" form field
+	// value is invalid.
+	body, contentType = test.URLMultipartForm(t, map[string]string{string(resource.CookiesArgKey): "not a JSON array"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "viewportWidth" form field
+	// value is invalid.
+	body, contentType = test.URLMultipartForm(t, map[string]string{string(resource.ViewportWidthArgKey): "-1"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "isMobile" form field
+	// value is invalid.
+	body, contentType = test.URLMultipartForm(t, map[string]string{string(resource.IsMobileArgKey): "not a boolean"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "blockedURLPatterns" form field
+	// value is invalid.
+	body, contentType = test.URLMultipartForm(t, map[string]string{string(resource.BlockedURLPatternsArgKey): "not a JSON array"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "disableJavaScript" form field
+	// value is invalid.
+	body, contentType = test.URLMultipartForm(t, map[string]string{string(resource.DisableJavaScriptArgKey): "not a boolean"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "waitForSelector" form field
+	// cannot be used with "disableJavaScript".
+	body, contentType = test.URLMultipartForm(t, map[string]string{
+		string(resource.DisableJavaScriptArgKey): "true",
+		string(resource.WaitForSelectorArgKey):   "#foo",
+	})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "generateDocumentOutline" form field
+	// value is invalid.
+	body, contentType = test.URLMultipartForm(t, map[string]string{string(resource.GenerateDocumentOutlineArgKey): "not a boolean"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "tableOfContents" form field
+	// value is invalid.
+	body, contentType = test.URLMultipartForm(t, map[string]string{string(resource.TableOfContentsArgKey): "not a boolean"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
 }
 
 func TestMarkdownHandler(t *testing.T) {
@@ -387,6 +596,15 @@ func TestMarkdownHandler(t *testing.T) {
 	req := httptest.NewRequest(http.MethodPost, endpoint, body)
 	req.Header.Set(echo.HeaderContentType, contentType)
 	test.AssertStatusCode(t, http.StatusOK, srv, req)
+	// should return 200 without index.html, with
+	// given Markdown files and page breaks.
+	body, contentType = test.MarkdownFilesMultipartForm(t, map[string]string{
+		string(resource.MarkdownFilesArgKey):      `["paragraph3.md", "paragraph1.md"]`,
+		string(resource.MarkdownPageBreaksArgKey): "1",
+	})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusOK, srv, req)
 	// should return 405 as Method is wrong.
 	req = httptest.NewRequest(http.MethodGet, endpoint, nil)
 	test.AssertStatusCode(t, http.StatusMethodNotAllowed, srv, req)
@@ -531,6 +749,117 @@ func TestMarkdownHandler(t *testing.T) {
 	req = httptest.NewRequest(http.MethodPost, endpoint, body)
 	req.Header.Set(echo.HeaderContentType, contentType)
 	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "emulatedMediaType" form field
+	// value is invalid.
+	body, contentType = test.MarkdownMultipartForm(t, map[string]string{string(resource.EmulatedMediaTypeArgKey): "foo"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "preferCSSPageSize" form field
+	// value is invalid.
+	body, contentType = test.MarkdownMultipartForm(t, map[string]string{string(resource.PreferCSSPageSizeArgKey): "not a boolean"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "failOnConsoleExceptions" form field
+	// value is invalid.
+	body, contentType = test.MarkdownMultipartForm(t, map[string]string{string(resource.FailOnConsoleExceptionsArgKey): "not a boolean"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "failOnHTTPStatusCodes" form field
+	// value is invalid.
+	body, contentType = test.MarkdownMultipartForm(t, map[string]string{string(resource.FailOnHTTPStatusCodesArgKey): "not a JSON array"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "failOnResourceLoadingFailed" form field
+	// value is invalid.
+	body, contentType = test.MarkdownMultipartForm(t, map[string]string{string(resource.FailOnResourceLoadingFailedArgKey): "not a boolean"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "viewportWidth" form field
+	// value is invalid.
+	body, contentType = test.MarkdownMultipartForm(t, map[string]string{string(resource.ViewportWidthArgKey): "-1"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "isMobile" form field
+	// value is invalid.
+	body, contentType = test.MarkdownMultipartForm(t, map[string]string{string(resource.IsMobileArgKey): "not a boolean"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "blockedURLPatterns" form field
+	// value is invalid.
+	body, contentType = test.MarkdownMultipartForm(t, map[string]string{string(resource.BlockedURLPatternsArgKey): "not a JSON array"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "disableJavaScript" form field
+	// value is invalid.
+	body, contentType = test.MarkdownMultipartForm(t, map[string]string{string(resource.DisableJavaScriptArgKey): "not a boolean"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "waitForSelector" form field
+	// cannot be used with "disableJavaScript".
+	body, contentType = test.MarkdownMultipartForm(t, map[string]string{
+		string(resource.DisableJavaScriptArgKey): "true",
+		string(resource.WaitForSelectorArgKey):   "#foo",
+	})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "generateDocumentOutline" form field
+	// value is invalid.
+	body, contentType = test.MarkdownMultipartForm(t, map[string]string{string(resource.GenerateDocumentOutlineArgKey): "not a boolean"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "tableOfContents" form field
+	// value is invalid.
+	body, contentType = test.MarkdownMultipartForm(t, map[string]string{string(resource.TableOfContentsArgKey): "not a boolean"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "markdownFiles" form field
+	// value contains a file which does not exist.
+	body, contentType = test.MarkdownFilesMultipartForm(t, map[string]string{string(resource.MarkdownFilesArgKey): `["foo.md"]`})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "markdownFiles" form field
+	// value is invalid.
+	body, contentType = test.MarkdownFilesMultipartForm(t, map[string]string{string(resource.MarkdownFilesArgKey): "foo"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "markdownPageBreaks" form field
+	// value is invalid.
+	body, contentType = test.MarkdownFilesMultipartForm(t, map[string]string{string(resource.MarkdownPageBreaksArgKey): "foo"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as the archive has
+	// an entry outside of its directory.
+	body, contentType = test.ArchiveMultipartForm(t, "zip_slip.zip", nil)
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "markdownTheme" form field
+	// value is invalid.
+	body, contentType = test.MarkdownMultipartForm(t, map[string]string{string(resource.MarkdownThemeArgKey): "foo"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "markdownSanitization" form field
+	// value is more permissive than allowed.
+	body, contentType = test.MarkdownMultipartForm(t, map[string]string{string(resource.MarkdownSanitizationArgKey): "none"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
 }
 
 func TestOfficeHandler(t *testing.T) {
@@ -543,6 +872,22 @@ func TestOfficeHandler(t *testing.T) {
 	req := httptest.NewRequest(http.MethodPost, endpoint, body)
 	req.Header.Set(echo.HeaderContentType, contentType)
 	test.AssertStatusCode(t, http.StatusOK, srv, req)
+	// should return 200 with "outputFormat" form field.
+	body, contentType = test.OfficeMultipartForm(t, map[string]string{
+		string(resource.WaitTimeoutArgKey):  "30",
+		string(resource.OutputFormatArgKey): "odt",
+	})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusOK, srv, req)
+	// should return 200 with "documentPassword" form field.
+	body, contentType = test.ProtectedOfficeMultipartForm(t, map[string]string{
+		string(resource.WaitTimeoutArgKey):      "30",
+		string(resource.DocumentPasswordArgKey): "foo",
+	})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusOK, srv, req)
 	// should return 405 as Method is wrong.
 	req = httptest.NewRequest(http.MethodGet, endpoint, nil)
 	test.AssertStatusCode(t, http.StatusMethodNotAllowed, srv, req)
@@ -579,6 +924,98 @@ func TestOfficeHandler(t *testing.T) {
 	req = httptest.NewRequest(http.MethodPost, endpoint, body)
 	req.Header.Set(echo.HeaderContentType, contentType)
 	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "stampPosition" form field
+	// value is invalid.
+	body, contentType = test.OfficeMultipartForm(t, map[string]string{string(resource.StampPositionArgKey): "middle"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "stampFontSize" form field
+	// value is < 4.
+	body, contentType = test.OfficeMultipartForm(t, map[string]string{string(resource.StampFontSizeArgKey): "1"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "csvDelimiter" form field
+	// value is invalid.
+	body, contentType = test.OfficeMultipartForm(t, map[string]string{string(resource.CSVDelimiterArgKey): "foo"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "csvEncoding" form field
+	// value is invalid.
+	body, contentType = test.OfficeMultipartForm(t, map[string]string{string(resource.CSVEncodingArgKey): "foo"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "outputFormat" form field
+	// value is invalid.
+	body, contentType = test.OfficeMultipartForm(t, map[string]string{string(resource.OutputFormatArgKey): "foo"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as text documents
+	// cannot be converted to "csv".
+	body, contentType = test.OfficeMultipartForm(t, map[string]string{string(resource.OutputFormatArgKey): "csv"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as page numbers cannot
+	// be stamped on an "odt" file.
+	body, contentType = test.OfficeMultipartForm(t, map[string]string{
+		string(resource.OutputFormatArgKey): "odt",
+		string(resource.StampFormatArgKey):  "Page {page} of {total}",
+	})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as PDF export options
+	// cannot be applied to an "odt" file.
+	body, contentType = test.OfficeMultipartForm(t, map[string]string{
+		string(resource.OutputFormatArgKey): "odt",
+		string(resource.QualityArgKey):      "50",
+	})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "quality" form field
+	// value is > 100.
+	body, contentType = test.OfficeMultipartForm(t, map[string]string{string(resource.QualityArgKey): "101"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as "maxImageResolution" form field
+	// value is invalid.
+	body, contentType = test.OfficeMultipartForm(t, map[string]string{string(resource.MaxImageResolutionArgKey): "100"})
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+	// should return 400 as the document
+	// requires a password.
+	body, contentType = test.ProtectedOfficeMultipartForm(t, nil)
+	req = httptest.NewRequest(http.MethodPost, endpoint, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	test.AssertStatusCode(t, http.StatusBadRequest, srv, req)
+}
+
+func TestConsoleMessagesHeader(t *testing.T) {
+	// small enough.
+	header, err := consoleMessagesHeader([]string{"log: foo", "error: bar"})
+	assert.Nil(t, err)
+	assert.Equal(t, `["log: foo","error: bar"]`, header)
+	// too large: 50 messages of 200 characters.
+	messages := make([]string, 50)
+	for i := range messages {
+		messages[i] = strings.Repeat("a", 200)
+	}
+	header, err = consoleMessagesHeader(messages)
+	assert.Nil(t, err)
+	assert.LessOrEqual(t, len(header), maxConsoleMessagesHTTPHeaderSize)
+	var result []string
+	err = json.Unmarshal([]byte(header), &result)
+	assert.Nil(t, err)
+	assert.Equal(t, messages[0], result[0])
+	assert.Equal(t, fmt.Sprintf("%d more message(s) truncated", 50-len(result)+1), result[len(result)-1])
 }
 
 func TestWebhook(t *testing.T) {
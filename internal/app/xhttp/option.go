@@ -1,6 +1,8 @@
 package xhttp
 
 import (
+	"fmt"
+
 	"github.com/thecodingmachine/gotenberg/internal/app/xhttp/pkg/resource"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/printer"
@@ -13,8 +15,13 @@ func mergePrinterOptions(r resource.Resource, config conf.Config) (printer.Merge
 	if err != nil {
 		return printer.MergePrinterOptions{}, xerror.New(op, err)
 	}
+	stamp, err := resource.StampArgs(r)
+	if err != nil {
+		return printer.MergePrinterOptions{}, xerror.New(op, err)
+	}
 	return printer.MergePrinterOptions{
 		WaitTimeout: waitTimeout,
+		Stamp:       stamp,
 	}, nil
 }
 
@@ -60,22 +67,178 @@ func chromePrinterOptions(r resource.Resource, config conf.Config) (printer.Chro
 		if err != nil {
 			return printer.ChromePrinterOptions{}, err
 		}
+		emulatedMediaType, err := resource.EmulatedMediaTypeArg(r, config)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		preferCSSPageSize, err := r.BoolArg(resource.PreferCSSPageSizeArgKey, false)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		waitForExpression, err := r.StringArg(resource.WaitForExpressionArgKey, "")
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		waitForSelector, err := r.StringArg(resource.WaitForSelectorArgKey, "")
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		failOnConsoleExceptions, err := r.BoolArg(resource.FailOnConsoleExceptionsArgKey, false)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		failOnHTTPStatusCodes, err := resource.FailOnHTTPStatusCodesArg(r)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		failOnResourceLoadingFailed, err := r.BoolArg(resource.FailOnResourceLoadingFailedArgKey, false)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		userAgent, err := r.StringArg(resource.UserAgentArgKey, "")
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		viewportWidth, viewportHeight,
+			err := resource.ViewportArgs(r, config)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		deviceScaleFactor, err := resource.DeviceScaleFactorArg(r, config)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		isMobile, err := r.BoolArg(resource.IsMobileArgKey, false)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		timezone, err := r.StringArg(resource.TimezoneArgKey, "")
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		locale, err := r.StringArg(resource.LocaleArgKey, "")
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		allowedURLPatterns, blockedURLPatterns,
+			err := resource.URLPatternsArgs(r)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		restrictToDocumentOrigin, err := r.BoolArg(resource.RestrictToDocumentOriginArgKey, false)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		disableJavaScript, err := r.BoolArg(resource.DisableJavaScriptArgKey, false)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		if disableJavaScript && (waitForExpression != "" || waitForSelector != "") {
+			return printer.ChromePrinterOptions{}, xerror.Invalid(
+				op,
+				fmt.Sprintf(
+					"'%s' and '%s' cannot be used with '%s'",
+					resource.WaitForExpressionArgKey,
+					resource.WaitForSelectorArgKey,
+					resource.DisableJavaScriptArgKey,
+				),
+				nil,
+			)
+		}
+		generateTaggedPDF, err := r.BoolArg(resource.GenerateTaggedPDFArgKey, false)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		generateDocumentOutline, err := r.BoolArg(resource.GenerateDocumentOutlineArgKey, false)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		headerFooterVariants, err := resource.HeaderFooterVariantsContents(r)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
+		tableOfContents, err := r.BoolArg(resource.TableOfContentsArgKey, false)
+		if err != nil {
+			return printer.ChromePrinterOptions{}, err
+		}
 		return printer.ChromePrinterOptions{
-			WaitTimeout:       waitTimeout,
-			WaitDelay:         waitDelay,
-			HeaderHTML:        headerHTML,
-			FooterHTML:        footerHTML,
-			PaperWidth:        paperWidth,
-			PaperHeight:       paperHeight,
-			MarginTop:         marginTop,
-			MarginBottom:      marginBottom,
-			MarginLeft:        marginLeft,
-			MarginRight:       marginRight,
-			Landscape:         landscape,
-			PageRanges:        pageRanges,
-			RpccBufferSize:    googleChromeRpccBufferSize,
-			CustomHTTPHeaders: make(map[string]string),
-			Scale:             scale,
+			WaitTimeout:                 waitTimeout,
+			WaitDelay:                   waitDelay,
+			HeaderHTML:                  headerHTML,
+			FooterHTML:                  footerHTML,
+			PaperWidth:                  paperWidth,
+			PaperHeight:                 paperHeight,
+			MarginTop:                   marginTop,
+			MarginBottom:                marginBottom,
+			MarginLeft:                  marginLeft,
+			MarginRight:                 marginRight,
+			Landscape:                   landscape,
+			PageRanges:                  pageRanges,
+			RpccBufferSize:              googleChromeRpccBufferSize,
+			CustomHTTPHeaders:           make(map[string]string),
+			Scale:                       scale,
+			EmulatedMediaType:           emulatedMediaType,
+			PreferCSSPageSize:           preferCSSPageSize,
+			WaitForExpression:           waitForExpression,
+			WaitForSelector:             waitForSelector,
+			FailOnConsoleExceptions:     failOnConsoleExceptions,
+			FailOnHTTPStatusCodes:       failOnHTTPStatusCodes,
+			FailOnResourceLoadingFailed: failOnResourceLoadingFailed,
+			UserAgent:                   userAgent,
+			ViewportWidth:               viewportWidth,
+			ViewportHeight:              viewportHeight,
+			DeviceScaleFactor:           deviceScaleFactor,
+			IsMobile:                    isMobile,
+			Timezone:                    timezone,
+			Locale:                      locale,
+			AllowedURLPatterns:          allowedURLPatterns,
+			BlockedURLPatterns:          blockedURLPatterns,
+			GlobalAllowedURLPatterns:    config.GoogleChromeAllowedURLPatterns(),
+			GlobalBlockedURLPatterns:    config.GoogleChromeBlockedURLPatterns(),
+			RestrictToDocumentOrigin:    restrictToDocumentOrigin,
+			DisableJavaScript:           disableJavaScript,
+			GenerateTaggedPDF:           generateTaggedPDF,
+			GenerateDocumentOutline:     generateDocumentOutline,
+			HeaderFooterVariants:        headerFooterVariants,
+			TableOfContents:             tableOfContents,
+		}, nil
+	}
+	opts, err := resolver()
+	if err != nil {
+		return opts, xerror.New(op, err)
+	}
+	return opts, nil
+}
+
+func markdownPrinterOptions(r resource.Resource, config conf.Config) (printer.MarkdownPrinterOptions, error) {
+	const op string = "xhttp.markdownPrinterOptions"
+	resolver := func() (printer.MarkdownPrinterOptions, error) {
+		chromeOpts, err := chromePrinterOptions(r, config)
+		if err != nil {
+			return printer.MarkdownPrinterOptions{}, err
+		}
+		theme, err := resource.MarkdownThemeArg(r, config)
+		if err != nil {
+			return printer.MarkdownPrinterOptions{}, err
+		}
+		sanitization, err := resource.MarkdownSanitizationArg(r, config)
+		if err != nil {
+			return printer.MarkdownPrinterOptions{}, err
+		}
+		files, err := resource.MarkdownFilesArg(r)
+		if err != nil {
+			return printer.MarkdownPrinterOptions{}, err
+		}
+		pageBreaks, err := r.BoolArg(resource.MarkdownPageBreaksArgKey, false)
+		if err != nil {
+			return printer.MarkdownPrinterOptions{}, err
+		}
+		return printer.MarkdownPrinterOptions{
+			Chrome:       chromeOpts,
+			Theme:        theme,
+			Sanitization: sanitization,
+			Files:        files,
+			PageBreaks:   pageBreaks,
 		}, nil
 	}
 	opts, err := resolver()
@@ -100,10 +263,40 @@ func officePrinterOptions(r resource.Resource, config conf.Config) (printer.Offi
 		if err != nil {
 			return printer.OfficePrinterOptions{}, err
 		}
+		csvDelimiter, err := resource.CSVDelimiterArg(r, config)
+		if err != nil {
+			return printer.OfficePrinterOptions{}, err
+		}
+		csvEncoding, err := resource.CSVEncodingArg(r, config)
+		if err != nil {
+			return printer.OfficePrinterOptions{}, err
+		}
+		outputFormat, err := resource.OfficeOutputFormatArg(r, config)
+		if err != nil {
+			return printer.OfficePrinterOptions{}, err
+		}
+		documentPassword, err := r.StringArg(resource.DocumentPasswordArgKey, "")
+		if err != nil {
+			return printer.OfficePrinterOptions{}, err
+		}
+		pdfExport, err := resource.PDFExportArgs(r)
+		if err != nil {
+			return printer.OfficePrinterOptions{}, err
+		}
+		stamp, err := resource.StampArgs(r)
+		if err != nil {
+			return printer.OfficePrinterOptions{}, err
+		}
 		return printer.OfficePrinterOptions{
-			WaitTimeout: waitTimeout,
-			Landscape:   landscape,
-			PageRanges:  pageRanges,
+			WaitTimeout:      waitTimeout,
+			Landscape:        landscape,
+			PageRanges:       pageRanges,
+			CSVDelimiter:     csvDelimiter,
+			CSVEncoding:      csvEncoding,
+			OutputFormat:     outputFormat,
+			DocumentPassword: documentPassword,
+			PDFExport:        pdfExport,
+			Stamp:            stamp,
 		}, nil
 	}
 	opts, err := resolver()
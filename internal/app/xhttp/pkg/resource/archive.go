@@ -0,0 +1,246 @@
+package resource
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+)
+
+const (
+	// maximumArchiveFiles is the maximum
+	// number of entries of an archive.
+	maximumArchiveFiles int = 1000
+	// maximumArchiveSize is the maximum size
+	// of the extracted files of an archive.
+	maximumArchiveSize int64 = 512 << 20
+	// maximumCompressionRatio is the maximum
+	// compression ratio of an archive entry.
+	maximumCompressionRatio int64 = 100
+	// compressionRatioThreshold is the size under
+	// which an entry may have any compression ratio.
+	compressionRatioThreshold int64 = 1 << 20
+)
+
+/*
+ExtractArchives extracts the ZIP archives of the
+Resource into its directory, keeping their
+directory structure.
+
+The extracted files are added to the Resource,
+identified by their relative path (e.g. "css/style.css"),
+while the archives are removed. If all the entries
+of an archive share a root directory, this directory
+is skipped.
+*/
+func (r Resource) ExtractArchives() error {
+	const op string = "resource.Resource.ExtractArchives"
+	var archives []string
+	for filename := range r.files {
+		if strings.ToLower(filepath.Ext(filename)) == ".zip" {
+			archives = append(archives, filename)
+		}
+	}
+	for _, filename := range archives {
+		fpath := r.files[filename].fpath
+		if err := r.extractArchive(fpath); err != nil {
+			return xerror.New(op, err)
+		}
+		delete(r.files, filename)
+		if err := os.Remove(fpath); err != nil {
+			return xerror.New(op, err)
+		}
+		r.logger.DebugOpf(op, "archive '%s' extracted", filename)
+	}
+	return nil
+}
+
+/*
+extractArchive extracts the ZIP archive
+at given path into the Resource directory.
+
+It rejects the entries which are symbolic links
+or target a path outside of this directory, and
+the archives which might be zip bombs.
+*/
+func (r Resource) extractArchive(fpath string) error {
+	const op string = "resource.Resource.extractArchive"
+	resolver := func() error {
+		reader, err := zip.OpenReader(fpath)
+		if err != nil {
+			return xerror.Invalid(
+				op,
+				fmt.Sprintf("'%s' is not a valid ZIP archive", filepath.Base(fpath)),
+				err,
+			)
+		}
+		defer reader.Close() // nolint: errcheck
+		if len(reader.File) > maximumArchiveFiles {
+			return xerror.Invalid(
+				op,
+				fmt.Sprintf("ZIP archive has more than '%d' entries", maximumArchiveFiles),
+				nil,
+			)
+		}
+		var (
+			entries []*zip.File
+			names   []string
+		)
+		for _, entry := range reader.File {
+			name, err := archiveEntryName(entry)
+			if err != nil {
+				return err
+			}
+			if entry.Mode().IsDir() || name == "" {
+				continue
+			}
+			entries = append(entries, entry)
+			names = append(names, name)
+		}
+		names = trimArchiveRoot(names)
+		remaining := maximumArchiveSize
+		for i, entry := range entries {
+			written, err := r.extractArchiveEntry(entry, names[i], remaining)
+			if err != nil {
+				return err
+			}
+			remaining -= written
+		}
+		return nil
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+/*
+archiveEntryName returns the cleaned relative
+path of given entry.
+
+It returns an error if the entry is not a
+directory nor a regular file, or if its
+path is absolute or goes up the tree.
+*/
+func archiveEntryName(entry *zip.File) (string, error) {
+	const op string = "resource.archiveEntryName"
+	mode := entry.Mode()
+	if !mode.IsDir() && !mode.IsRegular() {
+		return "", xerror.Invalid(
+			op,
+			fmt.Sprintf("ZIP archive entry '%s' is not a regular file", entry.Name),
+			nil,
+		)
+	}
+	name := strings.ReplaceAll(entry.Name, `\`, "/")
+	cleaned := path.Clean(name)
+	if path.IsAbs(name) ||
+		strings.Contains(cleaned, ":") ||
+		cleaned == ".." ||
+		strings.HasPrefix(cleaned, "../") {
+		return "", xerror.Invalid(
+			op,
+			fmt.Sprintf("ZIP archive entry '%s' targets a path outside of the archive", entry.Name),
+			nil,
+		)
+	}
+	if cleaned == "." {
+		return "", nil
+	}
+	return cleaned, nil
+}
+
+// trimArchiveRoot removes the root directory
+// shared by all given file paths, if any.
+func trimArchiveRoot(names []string) []string {
+	var root string
+	for _, name := range names {
+		i := strings.Index(name, "/")
+		if i < 0 {
+			return names
+		}
+		if root == "" {
+			root = name[:i+1]
+		}
+		if !strings.HasPrefix(name, root) {
+			return names
+		}
+	}
+	result := make([]string, len(names))
+	for i, name := range names {
+		result[i] = strings.TrimPrefix(name, root)
+	}
+	return result
+}
+
+/*
+extractArchiveEntry writes given entry to the
+Resource directory, and returns the number
+of written bytes.
+
+It returns an error if the entry is bigger
+than the remaining size, or if its compression
+ratio is suspicious.
+*/
+func (r Resource) extractArchiveEntry(entry *zip.File, name string, remaining int64) (int64, error) {
+	const op string = "resource.Resource.extractArchiveEntry"
+	bomb := xerror.Invalid(
+		op,
+		fmt.Sprintf("ZIP archive entry '%s' is too big or too compressed", entry.Name),
+		nil,
+	)
+	if entry.UncompressedSize64 > uint64(remaining) {
+		return 0, bomb
+	}
+	size := int64(entry.UncompressedSize64)
+	if size > compressionRatioThreshold &&
+		size > int64(entry.CompressedSize64)*maximumCompressionRatio {
+		return 0, bomb
+	}
+	if _, ok := r.files[name]; ok {
+		return 0, xerror.Invalid(
+			op,
+			fmt.Sprintf("ZIP archive entry '%s' conflicts with another file", entry.Name),
+			nil,
+		)
+	}
+	resolver := func() (int64, error) {
+		fpath := filepath.Join(r.dirPath, filepath.FromSlash(name))
+		if !strings.HasPrefix(fpath, r.dirPath+string(os.PathSeparator)) {
+			return 0, fmt.Errorf("'%s' is outside of the resource directory", fpath)
+		}
+		if err := os.MkdirAll(filepath.Dir(fpath), 0755); err != nil {
+			return 0, err
+		}
+		in, err := entry.Open()
+		if err != nil {
+			return 0, err
+		}
+		defer in.Close() // nolint: errcheck
+		out, err := os.OpenFile(fpath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err != nil {
+			return 0, err
+		}
+		defer out.Close() // nolint: errcheck
+		// do not trust the size from the headers.
+		written, err := io.Copy(out, io.LimitReader(in, remaining+1))
+		if err != nil {
+			return written, err
+		}
+		if written > remaining {
+			return written, bomb
+		}
+		r.files[name] = file{fpath: fpath}
+		return written, nil
+	}
+	written, err := resolver()
+	if err != nil {
+		return written, xerror.New(op, err)
+	}
+	return written, nil
+}
@@ -0,0 +1,140 @@
+package resource
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/test"
+)
+
+type archiveEntry struct {
+	name    string
+	content string
+	mode    os.FileMode
+}
+
+func archive(t *testing.T, entries ...archiveEntry) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+	for _, entry := range entries {
+		header := &zip.FileHeader{Name: entry.name, Method: zip.Deflate}
+		mode := entry.mode
+		if mode == 0 {
+			mode = 0644
+		}
+		header.SetMode(mode)
+		f, err := w.CreateHeader(header)
+		require.Nil(t, err)
+		_, err = f.Write([]byte(entry.content))
+		require.Nil(t, err)
+	}
+	require.Nil(t, w.Close())
+	return buf
+}
+
+func TestExtractArchives(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	logger := test.DebugLogger()
+	newResource := func(filename string, entries ...archiveEntry) Resource {
+		r, err := New(logger, resourceDirectoryName)
+		require.Nil(t, err)
+		err = r.WithFile(filename, archive(t, entries...))
+		require.Nil(t, err)
+		return r
+	}
+	// archive with a root directory.
+	r := newResource(
+		"site.zip",
+		archiveEntry{name: "site/", mode: os.ModeDir | 0755},
+		archiveEntry{name: "site/index.html", content: "<html></html>"},
+		archiveEntry{name: "site/css/style.css", content: "body {}"},
+	)
+	err := r.ExtractArchives()
+	assert.Nil(t, err)
+	content, err := r.Fcontent("css/style.css", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "body {}", content)
+	fpath, err := r.Fpath("index.html")
+	assert.Nil(t, err)
+	assert.Equal(t, r.DirPath()+"/index.html", fpath)
+	_, err = r.Fpath("site.zip")
+	test.AssertError(t, err)
+	assert.Nil(t, r.Close())
+	// archive without a root directory.
+	r = newResource(
+		"site.ZIP",
+		archiveEntry{name: "index.html", content: "<html></html>"},
+		archiveEntry{name: "./img/../css/style.css", content: "body {}"},
+	)
+	err = r.ExtractArchives()
+	assert.Nil(t, err)
+	_, err = r.Fpath("index.html")
+	assert.Nil(t, err)
+	_, err = r.Fpath("css/style.css")
+	assert.Nil(t, err)
+	assert.Nil(t, r.Close())
+	// should not be OK as an entry
+	// targets a parent directory.
+	r = newResource(
+		"site.zip",
+		archiveEntry{name: "index.html", content: "<html></html>"},
+		archiveEntry{name: "../../evil.html", content: "evil"},
+	)
+	err = r.ExtractArchives()
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	assert.Nil(t, r.Close())
+	// should not be OK as an entry
+	// has an absolute path.
+	r = newResource("site.zip", archiveEntry{name: "/etc/evil", content: "evil"})
+	err = r.ExtractArchives()
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	assert.Nil(t, r.Close())
+	// should not be OK as an entry
+	// is a symbolic link.
+	r = newResource("site.zip", archiveEntry{name: "passwd", content: "/etc/passwd", mode: os.ModeSymlink | 0777})
+	err = r.ExtractArchives()
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	assert.Nil(t, r.Close())
+	// should not be OK as an entry
+	// is too compressed.
+	r = newResource("bomb.zip", archiveEntry{name: "bomb.txt", content: strings.Repeat("0", 10<<20)})
+	err = r.ExtractArchives()
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	assert.Nil(t, r.Close())
+	// should not be OK as an entry
+	// conflicts with another file.
+	r = newResource("site.zip", archiveEntry{name: "index.html", content: "<html></html>"})
+	err = r.WithFile("index.html", strings.NewReader("<html></html>"))
+	require.Nil(t, err)
+	err = r.ExtractArchives()
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	assert.Nil(t, r.Close())
+	// should not be OK as the
+	// archive is invalid.
+	r, err = New(logger, resourceDirectoryName)
+	require.Nil(t, err)
+	err = r.WithFile("site.zip", strings.NewReader("foo"))
+	require.Nil(t, err)
+	err = r.ExtractArchives()
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	assert.Nil(t, r.Close())
+}
+
+func TestTrimArchiveRoot(t *testing.T) {
+	assert.Equal(t, []string{"index.html", "css/style.css"}, trimArchiveRoot([]string{"site/index.html", "site/css/style.css"}))
+	assert.Equal(t, []string{"a/index.html", "b/style.css"}, trimArchiveRoot([]string{"a/index.html", "b/style.css"}))
+	assert.Equal(t, []string{"index.html", "css/style.css"}, trimArchiveRoot([]string{"index.html", "css/style.css"}))
+	assert.Equal(t, []string{}, trimArchiveRoot([]string{}))
+}
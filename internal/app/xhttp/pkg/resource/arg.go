@@ -1,6 +1,12 @@
 package resource
 
 import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
 	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/printer"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xassert"
@@ -60,6 +66,141 @@ const (
 	// ScaleArgKey is the key
 	// of the argument "scale".
 	ScaleArgKey ArgKey = "scale"
+	// EmulatedMediaTypeArgKey is the key
+	// of the argument "emulatedMediaType".
+	EmulatedMediaTypeArgKey ArgKey = "emulatedMediaType"
+	// PreferCSSPageSizeArgKey is the key
+	// of the argument "preferCSSPageSize".
+	PreferCSSPageSizeArgKey ArgKey = "preferCSSPageSize"
+	// WaitForExpressionArgKey is the key
+	// of the argument "waitForExpression".
+	WaitForExpressionArgKey ArgKey = "waitForExpression"
+	// WaitForSelectorArgKey is the key
+	// of the argument "waitForSelector".
+	WaitForSelectorArgKey ArgKey = "waitForSelector"
+	// FailOnConsoleExceptionsArgKey is the key
+	// of the argument "failOnConsoleExceptions".
+	FailOnConsoleExceptionsArgKey ArgKey = "failOnConsoleExceptions"
+	// FailOnHTTPStatusCodesArgKey is the key
+	// of the argument "failOnHTTPStatusCodes".
+	FailOnHTTPStatusCodesArgKey ArgKey = "failOnHTTPStatusCodes"
+	// FailOnResourceLoadingFailedArgKey is the key
+	// of the argument "failOnResourceLoadingFailed".
+	FailOnResourceLoadingFailedArgKey ArgKey = "failOnResourceLoadingFailed"
+	// CookiesArgKey is the key
+	// of the argument "cookies".
+	CookiesArgKey ArgKey = "cookies"
+	// UserAgentArgKey is the key
+	// of the argument "userAgent".
+	UserAgentArgKey ArgKey = "userAgent"
+	// ViewportWidthArgKey is the key
+	// of the argument "viewportWidth".
+	ViewportWidthArgKey ArgKey = "viewportWidth"
+	// ViewportHeightArgKey is the key
+	// of the argument "viewportHeight".
+	ViewportHeightArgKey ArgKey = "viewportHeight"
+	// DeviceScaleFactorArgKey is the key
+	// of the argument "deviceScaleFactor".
+	DeviceScaleFactorArgKey ArgKey = "deviceScaleFactor"
+	// IsMobileArgKey is the key
+	// of the argument "isMobile".
+	IsMobileArgKey ArgKey = "isMobile"
+	// TimezoneArgKey is the key
+	// of the argument "timezone".
+	TimezoneArgKey ArgKey = "timezone"
+	// LocaleArgKey is the key
+	// of the argument "locale".
+	LocaleArgKey ArgKey = "locale"
+	// AllowedURLPatternsArgKey is the key
+	// of the argument "allowedURLPatterns".
+	AllowedURLPatternsArgKey ArgKey = "allowedURLPatterns"
+	// BlockedURLPatternsArgKey is the key
+	// of the argument "blockedURLPatterns".
+	BlockedURLPatternsArgKey ArgKey = "blockedURLPatterns"
+	// RestrictToDocumentOriginArgKey is the key
+	// of the argument "restrictToDocumentOrigin".
+	RestrictToDocumentOriginArgKey ArgKey = "restrictToDocumentOrigin"
+	// DisableJavaScriptArgKey is the key
+	// of the argument "disableJavaScript".
+	DisableJavaScriptArgKey ArgKey = "disableJavaScript"
+	// GenerateTaggedPDFArgKey is the key
+	// of the argument "generateTaggedPDF".
+	GenerateTaggedPDFArgKey ArgKey = "generateTaggedPDF"
+	// GenerateDocumentOutlineArgKey is the key
+	// of the argument "generateDocumentOutline".
+	GenerateDocumentOutlineArgKey ArgKey = "generateDocumentOutline"
+	// StampFormatArgKey is the key
+	// of the argument "stampFormat".
+	StampFormatArgKey ArgKey = "stampFormat"
+	// StampStartNumberArgKey is the key
+	// of the argument "stampStartNumber".
+	StampStartNumberArgKey ArgKey = "stampStartNumber"
+	// StampPositionArgKey is the key
+	// of the argument "stampPosition".
+	StampPositionArgKey ArgKey = "stampPosition"
+	// StampFontArgKey is the key
+	// of the argument "stampFont".
+	StampFontArgKey ArgKey = "stampFont"
+	// StampFontSizeArgKey is the key
+	// of the argument "stampFontSize".
+	StampFontSizeArgKey ArgKey = "stampFontSize"
+	// DataArgKey is the key
+	// of the argument "data".
+	DataArgKey ArgKey = "data"
+	// MarkdownThemeArgKey is the key
+	// of the argument "markdownTheme".
+	MarkdownThemeArgKey ArgKey = "markdownTheme"
+	// TableOfContentsArgKey is the key
+	// of the argument "tableOfContents".
+	TableOfContentsArgKey ArgKey = "tableOfContents"
+	// MarkdownSanitizationArgKey is the key
+	// of the argument "markdownSanitization".
+	MarkdownSanitizationArgKey ArgKey = "markdownSanitization"
+	// MarkdownFilesArgKey is the key
+	// of the argument "markdownFiles".
+	MarkdownFilesArgKey ArgKey = "markdownFiles"
+	// MarkdownPageBreaksArgKey is the key
+	// of the argument "markdownPageBreaks".
+	MarkdownPageBreaksArgKey ArgKey = "markdownPageBreaks"
+	// CSVDelimiterArgKey is the key
+	// of the argument "csvDelimiter".
+	CSVDelimiterArgKey ArgKey = "csvDelimiter"
+	// CSVEncodingArgKey is the key
+	// of the argument "csvEncoding".
+	CSVEncodingArgKey ArgKey = "csvEncoding"
+	// OutputFormatArgKey is the key
+	// of the argument "outputFormat".
+	OutputFormatArgKey ArgKey = "outputFormat"
+	// QualityArgKey is the key
+	// of the argument "quality".
+	QualityArgKey ArgKey = "quality"
+	// ReduceImageResolutionArgKey is the key
+	// of the argument "reduceImageResolution".
+	ReduceImageResolutionArgKey ArgKey = "reduceImageResolution"
+	// MaxImageResolutionArgKey is the key
+	// of the argument "maxImageResolution".
+	MaxImageResolutionArgKey ArgKey = "maxImageResolution"
+	// LosslessImageCompressionArgKey is the key
+	// of the argument "losslessImageCompression".
+	LosslessImageCompressionArgKey ArgKey = "losslessImageCompression"
+	// ExportBookmarksArgKey is the key
+	// of the argument "exportBookmarks".
+	ExportBookmarksArgKey ArgKey = "exportBookmarks"
+	// ExportNotesArgKey is the key
+	// of the argument "exportNotes".
+	ExportNotesArgKey ArgKey = "exportNotes"
+	// ExportNotesPagesArgKey is the key
+	// of the argument "exportNotesPages".
+	ExportNotesPagesArgKey ArgKey = "exportNotesPages"
+	// ExportFormFieldsArgKey is the key
+	// of the argument "exportFormFields".
+	ExportFormFieldsArgKey ArgKey = "exportFormFields"
+	// SkipEmptyPagesArgKey is the key
+	// of the argument "skipEmptyPages".
+	SkipEmptyPagesArgKey ArgKey = "skipEmptyPages"
+	// DocumentPasswordArgKey is the key
+	// of the argument "documentPassword".
+	DocumentPasswordArgKey ArgKey = "documentPassword"
 )
 
 /*
@@ -85,6 +226,51 @@ func ArgKeys() []ArgKey {
 		PageRangesArgKey,
 		GoogleChromeRpccBufferSizeArgKey,
 		ScaleArgKey,
+		EmulatedMediaTypeArgKey,
+		PreferCSSPageSizeArgKey,
+		WaitForExpressionArgKey,
+		WaitForSelectorArgKey,
+		FailOnConsoleExceptionsArgKey,
+		FailOnHTTPStatusCodesArgKey,
+		FailOnResourceLoadingFailedArgKey,
+		CookiesArgKey,
+		UserAgentArgKey,
+		ViewportWidthArgKey,
+		ViewportHeightArgKey,
+		DeviceScaleFactorArgKey,
+		IsMobileArgKey,
+		TimezoneArgKey,
+		LocaleArgKey,
+		AllowedURLPatternsArgKey,
+		BlockedURLPatternsArgKey,
+		RestrictToDocumentOriginArgKey,
+		DisableJavaScriptArgKey,
+		GenerateTaggedPDFArgKey,
+		GenerateDocumentOutlineArgKey,
+		StampFormatArgKey,
+		StampStartNumberArgKey,
+		StampPositionArgKey,
+		StampFontArgKey,
+		StampFontSizeArgKey,
+		DataArgKey,
+		MarkdownThemeArgKey,
+		TableOfContentsArgKey,
+		MarkdownSanitizationArgKey,
+		MarkdownFilesArgKey,
+		MarkdownPageBreaksArgKey,
+		CSVDelimiterArgKey,
+		CSVEncodingArgKey,
+		OutputFormatArgKey,
+		QualityArgKey,
+		ReduceImageResolutionArgKey,
+		MaxImageResolutionArgKey,
+		LosslessImageCompressionArgKey,
+		ExportBookmarksArgKey,
+		ExportNotesArgKey,
+		ExportNotesPagesArgKey,
+		ExportFormFieldsArgKey,
+		SkipEmptyPagesArgKey,
+		DocumentPasswordArgKey,
 	}
 }
 
@@ -317,3 +503,562 @@ func ScaleArg(r Resource, config conf.Config) (float64, error) {
 	}
 	return result, nil
 }
+
+/*
+ViewportArgs is a helper for retrieving
+the "viewportWidth" and "viewportHeight"
+arguments as int64.
+
+A value of 0 keeps the default value.
+*/
+func ViewportArgs(r Resource, config conf.Config) (int64, int64, error) {
+	const (
+		op                  string = "resource.ViewportArgs"
+		maximumViewportSize int64  = 10000
+	)
+	opts := printer.DefaultChromePrinterOptions(config)
+	resolver := func() (int64, int64, error) {
+		viewportWidth, err := r.Int64Arg(
+			ViewportWidthArgKey,
+			opts.ViewportWidth,
+			xassert.Int64NotInferiorTo(0),
+			xassert.Int64NotSuperiorTo(maximumViewportSize),
+		)
+		if err != nil {
+			return opts.ViewportWidth,
+				opts.ViewportHeight,
+				err
+		}
+		viewportHeight, err := r.Int64Arg(
+			ViewportHeightArgKey,
+			opts.ViewportHeight,
+			xassert.Int64NotInferiorTo(0),
+			xassert.Int64NotSuperiorTo(maximumViewportSize),
+		)
+		if err != nil {
+			return opts.ViewportWidth,
+				opts.ViewportHeight,
+				err
+		}
+		return viewportWidth,
+			viewportHeight,
+			nil
+	}
+	viewportWidth, viewportHeight,
+		err := resolver()
+	if err != nil {
+		return viewportWidth,
+			viewportHeight,
+			xerror.New(op, err)
+	}
+	return viewportWidth,
+		viewportHeight,
+		nil
+}
+
+/*
+DeviceScaleFactorArg is a helper for retrieving
+the "deviceScaleFactor" argument as float64.
+*/
+func DeviceScaleFactorArg(r Resource, config conf.Config) (float64, error) {
+	const op string = "resource.DeviceScaleFactorArg"
+	opts := printer.DefaultChromePrinterOptions(config)
+	result, err := r.Float64Arg(
+		DeviceScaleFactorArgKey,
+		opts.DeviceScaleFactor,
+		xassert.Float64NotInferiorTo(0.1),
+		xassert.Float64NotSuperiorTo(10.0),
+	)
+	if err != nil {
+		return result, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+EmulatedMediaTypeArg is a helper for retrieving
+the "emulatedMediaType" argument as string.
+
+It accepts either "screen" or "print".
+*/
+func EmulatedMediaTypeArg(r Resource, config conf.Config) (string, error) {
+	const op string = "resource.EmulatedMediaTypeArg"
+	opts := printer.DefaultChromePrinterOptions(config)
+	result, err := r.StringArg(
+		EmulatedMediaTypeArgKey,
+		opts.EmulatedMediaType,
+		xassert.StringOneOf([]string{"screen", "print"}),
+	)
+	if err != nil {
+		return result, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+MarkdownThemeArg is a helper for retrieving
+the "markdownTheme" argument as string.
+*/
+func MarkdownThemeArg(r Resource, config conf.Config) (string, error) {
+	const op string = "resource.MarkdownThemeArg"
+	opts := printer.DefaultMarkdownPrinterOptions(config)
+	result, err := r.StringArg(
+		MarkdownThemeArgKey,
+		opts.Theme,
+		xassert.StringOneOf(printer.MarkdownThemes()),
+	)
+	if err != nil {
+		return result, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+MarkdownSanitizationArg is a helper for retrieving
+the "markdownSanitization" argument as string.
+
+It also validates it against the most permissive
+sanitization profile from the application
+configuration.
+*/
+func MarkdownSanitizationArg(r Resource, config conf.Config) (string, error) {
+	const op string = "resource.MarkdownSanitizationArg"
+	opts := printer.DefaultMarkdownPrinterOptions(config)
+	result, err := r.StringArg(
+		MarkdownSanitizationArgKey,
+		opts.Sanitization,
+		xassert.StringOneOf(config.AllowedMarkdownSanitizationProfiles()),
+	)
+	if err != nil {
+		return result, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+MarkdownFilesArg is a helper for retrieving
+the "markdownFiles" argument as []string.
+
+It expects a JSON array of Markdown filenames
+(e.g. ["intro.md", "chapters/one.md"]), which
+should exist within the Resource.
+*/
+func MarkdownFilesArg(r Resource) ([]string, error) {
+	const op string = "resource.MarkdownFilesArg"
+	resolver := func() ([]string, error) {
+		if !r.HasArg(MarkdownFilesArgKey) {
+			return nil, nil
+		}
+		value, err := r.StringArg(MarkdownFilesArgKey, "")
+		if err != nil {
+			return nil, err
+		}
+		var filenames []string
+		if err := json.Unmarshal([]byte(value), &filenames); err != nil {
+			return nil, xerror.Invalid(
+				op,
+				fmt.Sprintf("'%s' is not a JSON array of strings, got '%s'", MarkdownFilesArgKey, value),
+				err,
+			)
+		}
+		for _, filename := range filenames {
+			if !r.HasFile(filename) {
+				return nil, xerror.Invalid(
+					op,
+					fmt.Sprintf("'%s' contains '%s' which does not exist", MarkdownFilesArgKey, filename),
+					nil,
+				)
+			}
+		}
+		return filenames, nil
+	}
+	result, err := resolver()
+	if err != nil {
+		return nil, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+CSVDelimiterArg is a helper for retrieving
+the "csvDelimiter" argument as string.
+
+It expects a single ASCII character
+(e.g. ";" or a tab).
+*/
+func CSVDelimiterArg(r Resource, config conf.Config) (string, error) {
+	const op string = "resource.CSVDelimiterArg"
+	resolver := func() (string, error) {
+		opts := printer.DefaultOfficePrinterOptions(config)
+		if !r.HasArg(CSVDelimiterArgKey) {
+			return opts.CSVDelimiter, nil
+		}
+		value, err := r.StringArg(CSVDelimiterArgKey, opts.CSVDelimiter)
+		if err != nil {
+			return opts.CSVDelimiter, err
+		}
+		if len(value) != 1 || value[0] > unicode.MaxASCII || (value[0] < ' ' && value[0] != '\t') {
+			return opts.CSVDelimiter, xerror.Invalid(
+				op,
+				fmt.Sprintf("'%s' is not a single ASCII character, got '%s'", CSVDelimiterArgKey, value),
+				nil,
+			)
+		}
+		return value, nil
+	}
+	result, err := resolver()
+	if err != nil {
+		return result, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+CSVEncodingArg is a helper for retrieving
+the "csvEncoding" argument as string.
+*/
+func CSVEncodingArg(r Resource, config conf.Config) (string, error) {
+	const op string = "resource.CSVEncodingArg"
+	opts := printer.DefaultOfficePrinterOptions(config)
+	result, err := r.StringArg(
+		CSVEncodingArgKey,
+		opts.CSVEncoding,
+		xassert.StringOneOf(printer.CSVEncodings()),
+	)
+	if err != nil {
+		return result, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+OfficeOutputFormatArg is a helper for retrieving
+the "outputFormat" argument as string.
+*/
+func OfficeOutputFormatArg(r Resource, config conf.Config) (string, error) {
+	const op string = "resource.OfficeOutputFormatArg"
+	opts := printer.DefaultOfficePrinterOptions(config)
+	result, err := r.StringArg(
+		OutputFormatArgKey,
+		opts.OutputFormat,
+		xassert.StringOneOf(printer.OfficeOutputFormats()),
+	)
+	if err != nil {
+		return result, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+FailOnHTTPStatusCodesArg is a helper for retrieving
+the "failOnHTTPStatusCodes" argument as []int64.
+
+It expects a JSON array of HTTP status codes
+(e.g. [404, 599]).
+*/
+func FailOnHTTPStatusCodesArg(r Resource) ([]int64, error) {
+	const op string = "resource.FailOnHTTPStatusCodesArg"
+	resolver := func() ([]int64, error) {
+		if !r.HasArg(FailOnHTTPStatusCodesArgKey) {
+			return nil, nil
+		}
+		value, err := r.StringArg(FailOnHTTPStatusCodesArgKey, "")
+		if err != nil {
+			return nil, err
+		}
+		var codes []int64
+		if err := json.Unmarshal([]byte(value), &codes); err != nil {
+			return nil, xerror.Invalid(
+				op,
+				fmt.Sprintf("'%s' is not a JSON array of integers, got '%s'", FailOnHTTPStatusCodesArgKey, value),
+				err,
+			)
+		}
+		for _, code := range codes {
+			if code < 100 || code > 599 {
+				return nil, xerror.Invalid(
+					op,
+					fmt.Sprintf("'%s' should only contain HTTP status codes, got '%d'", FailOnHTTPStatusCodesArgKey, code),
+					nil,
+				)
+			}
+		}
+		return codes, nil
+	}
+	result, err := resolver()
+	if err != nil {
+		return nil, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+CookiesArg is a helper for retrieving
+the "cookies" argument as []printer.Cookie.
+
+It expects a JSON array of cookies
+(e.g. [{"name": "foo", "value": "bar"}]).
+*/
+func CookiesArg(r Resource) ([]printer.Cookie, error) {
+	const op string = "resource.CookiesArg"
+	resolver := func() ([]printer.Cookie, error) {
+		if !r.HasArg(CookiesArgKey) {
+			return nil, nil
+		}
+		value, err := r.StringArg(CookiesArgKey, "")
+		if err != nil {
+			return nil, err
+		}
+		var cookies []printer.Cookie
+		if err := json.Unmarshal([]byte(value), &cookies); err != nil {
+			return nil, xerror.Invalid(
+				op,
+				fmt.Sprintf("'%s' is not a JSON array of cookies, got '%s'", CookiesArgKey, value),
+				err,
+			)
+		}
+		for _, cookie := range cookies {
+			if cookie.Name == "" {
+				return nil, xerror.Invalid(
+					op,
+					fmt.Sprintf("'%s' should only contain cookies with a name", CookiesArgKey),
+					nil,
+				)
+			}
+			switch cookie.SameSite {
+			case "", "Strict", "Lax", "None":
+			default:
+				return nil, xerror.Invalid(
+					op,
+					fmt.Sprintf(
+						"'%s' should only contain cookies with a 'sameSite' value from '%v', got '%s'",
+						CookiesArgKey,
+						[]string{"Strict", "Lax", "None"},
+						cookie.SameSite,
+					),
+					nil,
+				)
+			}
+		}
+		return cookies, nil
+	}
+	result, err := resolver()
+	if err != nil {
+		return nil, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+URLPatternsArgs is a helper for retrieving
+the "allowedURLPatterns" and "blockedURLPatterns"
+arguments as []string.
+
+They expect a JSON array of URL patterns
+(e.g. ["*://*.doubleclick.net/*"]).
+*/
+func URLPatternsArgs(r Resource) ([]string, []string, error) {
+	const op string = "resource.URLPatternsArgs"
+	resolver := func(key ArgKey) ([]string, error) {
+		if !r.HasArg(key) {
+			return nil, nil
+		}
+		value, err := r.StringArg(key, "")
+		if err != nil {
+			return nil, err
+		}
+		var patterns []string
+		if err := json.Unmarshal([]byte(value), &patterns); err != nil {
+			return nil, xerror.Invalid(
+				op,
+				fmt.Sprintf("'%s' is not a JSON array of strings, got '%s'", key, value),
+				err,
+			)
+		}
+		return patterns, nil
+	}
+	allowed, err := resolver(AllowedURLPatternsArgKey)
+	if err != nil {
+		return nil, nil, xerror.New(op, err)
+	}
+	blocked, err := resolver(BlockedURLPatternsArgKey)
+	if err != nil {
+		return nil, nil, xerror.New(op, err)
+	}
+	return allowed, blocked, nil
+}
+
+/*
+StampArgs is a helper for retrieving the
+"stampFormat", "stampStartNumber",
+"stampPosition", "stampFont" and
+"stampFontSize" arguments as
+printer.StampOptions.
+*/
+func StampArgs(r Resource) (printer.StampOptions, error) {
+	const op string = "resource.StampArgs"
+	opts := printer.DefaultStampOptions()
+	resolver := func() (printer.StampOptions, error) {
+		format, err := r.StringArg(StampFormatArgKey, opts.Format)
+		if err != nil {
+			return opts, err
+		}
+		startNumber, err := r.Int64Arg(
+			StampStartNumberArgKey,
+			opts.StartNumber,
+			xassert.Int64NotInferiorTo(0),
+		)
+		if err != nil {
+			return opts, err
+		}
+		position, err := r.StringArg(
+			StampPositionArgKey,
+			opts.Position,
+			xassert.StringOneOf(printer.StampPositions()),
+		)
+		if err != nil {
+			return opts, err
+		}
+		font, err := r.StringArg(
+			StampFontArgKey,
+			opts.Font,
+			xassert.StringOneOf(printer.StampFonts()),
+		)
+		if err != nil {
+			return opts, err
+		}
+		fontSize, err := r.Float64Arg(
+			StampFontSizeArgKey,
+			opts.FontSize,
+			xassert.Float64NotInferiorTo(4.0),
+			xassert.Float64NotSuperiorTo(72.0),
+		)
+		if err != nil {
+			return opts, err
+		}
+		return printer.StampOptions{
+			Format:      format,
+			StartNumber: startNumber,
+			Position:    position,
+			Font:        font,
+			FontSize:    fontSize,
+		}, nil
+	}
+	result, err := resolver()
+	if err != nil {
+		return result, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+PDFExportArgs is a helper for retrieving
+the arguments of the LibreOffice PDF export
+filter as printer.PDFExportOptions.
+*/
+func PDFExportArgs(r Resource) (printer.PDFExportOptions, error) {
+	const op string = "resource.PDFExportArgs"
+	opts := printer.DefaultPDFExportOptions()
+	resolver := func() (printer.PDFExportOptions, error) {
+		// only the arguments sent by the client
+		// are given to LibreOffice, which uses
+		// its own defaults for the other ones.
+		if r.HasArg(QualityArgKey) {
+			quality, err := r.Int64Arg(
+				QualityArgKey,
+				0,
+				xassert.Int64NotInferiorTo(1),
+				xassert.Int64NotSuperiorTo(100),
+			)
+			if err != nil {
+				return opts, err
+			}
+			opts.Quality = &quality
+		}
+		if r.HasArg(MaxImageResolutionArgKey) {
+			maxImageResolution, err := r.StringArg(
+				MaxImageResolutionArgKey,
+				"",
+				xassert.StringOneOf(printer.PDFExportMaxImageResolutions()),
+			)
+			if err != nil {
+				return opts, err
+			}
+			// already validated.
+			resolution, _ := strconv.ParseInt(maxImageResolution, 10, 64)
+			opts.MaxImageResolution = &resolution
+		}
+		boolArgs := []struct {
+			key   ArgKey
+			value **bool
+		}{
+			{ReduceImageResolutionArgKey, &opts.ReduceImageResolution},
+			{LosslessImageCompressionArgKey, &opts.LosslessImageCompression},
+			{ExportBookmarksArgKey, &opts.ExportBookmarks},
+			{ExportNotesArgKey, &opts.ExportNotes},
+			{ExportNotesPagesArgKey, &opts.ExportNotesPages},
+			{ExportFormFieldsArgKey, &opts.ExportFormFields},
+			{SkipEmptyPagesArgKey, &opts.SkipEmptyPages},
+		}
+		for _, arg := range boolArgs {
+			if !r.HasArg(arg.key) {
+				continue
+			}
+			value, err := r.BoolArg(arg.key, false)
+			if err != nil {
+				return opts, err
+			}
+			*arg.value = &value
+		}
+		return opts, nil
+	}
+	result, err := resolver()
+	if err != nil {
+		return result, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+TemplateDataArg is a helper for retrieving
+the "data" argument, or the content of the
+file "data.json" if the argument is empty.
+
+It returns false if there is no data, i.e.
+no template to execute.
+*/
+func TemplateDataArg(r Resource) (interface{}, bool, error) {
+	const op string = "resource.TemplateDataArg"
+	resolver := func() (interface{}, bool, error) {
+		value, err := r.StringArg(DataArgKey, "")
+		if err != nil {
+			return nil, false, err
+		}
+		if value == "" {
+			value, err = r.Fcontent("data.json", "")
+			if err != nil {
+				return nil, false, err
+			}
+		}
+		if value == "" {
+			return nil, false, nil
+		}
+		// keeps the numbers as they have been sent.
+		decoder := json.NewDecoder(strings.NewReader(value))
+		decoder.UseNumber()
+		var data interface{}
+		if err := decoder.Decode(&data); err != nil {
+			return nil, false, xerror.Invalid(
+				op,
+				fmt.Sprintf("'%s' is not valid JSON data", DataArgKey),
+				err,
+			)
+		}
+		return data, true, nil
+	}
+	data, ok, err := resolver()
+	if err != nil {
+		return nil, false, xerror.New(op, err)
+	}
+	return data, ok, nil
+}
@@ -1,6 +1,8 @@
 package resource
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -27,6 +29,51 @@ func TestArgKeys(t *testing.T) {
 		PageRangesArgKey,
 		GoogleChromeRpccBufferSizeArgKey,
 		ScaleArgKey,
+		EmulatedMediaTypeArgKey,
+		PreferCSSPageSizeArgKey,
+		WaitForExpressionArgKey,
+		WaitForSelectorArgKey,
+		FailOnConsoleExceptionsArgKey,
+		FailOnHTTPStatusCodesArgKey,
+		FailOnResourceLoadingFailedArgKey,
+		CookiesArgKey,
+		UserAgentArgKey,
+		ViewportWidthArgKey,
+		ViewportHeightArgKey,
+		DeviceScaleFactorArgKey,
+		IsMobileArgKey,
+		TimezoneArgKey,
+		LocaleArgKey,
+		AllowedURLPatternsArgKey,
+		BlockedURLPatternsArgKey,
+		RestrictToDocumentOriginArgKey,
+		DisableJavaScriptArgKey,
+		GenerateTaggedPDFArgKey,
+		GenerateDocumentOutlineArgKey,
+		StampFormatArgKey,
+		StampStartNumberArgKey,
+		StampPositionArgKey,
+		StampFontArgKey,
+		StampFontSizeArgKey,
+		DataArgKey,
+		MarkdownThemeArgKey,
+		TableOfContentsArgKey,
+		MarkdownSanitizationArgKey,
+		MarkdownFilesArgKey,
+		MarkdownPageBreaksArgKey,
+		CSVDelimiterArgKey,
+		CSVEncodingArgKey,
+		OutputFormatArgKey,
+		QualityArgKey,
+		ReduceImageResolutionArgKey,
+		MaxImageResolutionArgKey,
+		LosslessImageCompressionArgKey,
+		ExportBookmarksArgKey,
+		ExportNotesArgKey,
+		ExportNotesPagesArgKey,
+		ExportFormFieldsArgKey,
+		SkipEmptyPagesArgKey,
+		DocumentPasswordArgKey,
 	}
 	assert.Equal(t, expected, ArgKeys())
 }
@@ -394,3 +441,616 @@ func TestScaleArg(t *testing.T) {
 	err = r.Close()
 	assert.Nil(t, err)
 }
+
+func TestEmulatedMediaTypeArg(t *testing.T) {
+	const (
+		resourceDirectoryName string = "foo"
+		defaultValue          string = "print"
+	)
+	var expected string
+	logger := test.DebugLogger()
+	config := conf.DefaultConfig()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// argument does not exist.
+	expected = defaultValue
+	v, err := EmulatedMediaTypeArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, v)
+	// argument exist.
+	expected = "screen"
+	r.WithArg(EmulatedMediaTypeArgKey, "screen")
+	v, err = EmulatedMediaTypeArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, v)
+	// should not be OK as
+	// argument value is invalid.
+	expected = defaultValue
+	r.WithArg(EmulatedMediaTypeArgKey, "foo")
+	v, err = EmulatedMediaTypeArg(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, expected, v)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestMarkdownThemeArg(t *testing.T) {
+	const (
+		resourceDirectoryName string = "foo"
+		defaultValue          string = "none"
+	)
+	var expected string
+	logger := test.DebugLogger()
+	config := conf.DefaultConfig()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// argument does not exist.
+	expected = defaultValue
+	v, err := MarkdownThemeArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, v)
+	// argument exist.
+	expected = "github"
+	r.WithArg(MarkdownThemeArgKey, "github")
+	v, err = MarkdownThemeArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, v)
+	// should not be OK as
+	// argument value is invalid.
+	expected = defaultValue
+	r.WithArg(MarkdownThemeArgKey, "foo")
+	v, err = MarkdownThemeArg(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, expected, v)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestMarkdownSanitizationArg(t *testing.T) {
+	const (
+		resourceDirectoryName string = "foo"
+		defaultValue          string = "ugc"
+	)
+	var expected string
+	logger := test.DebugLogger()
+	config := conf.DefaultConfig()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// argument does not exist.
+	expected = defaultValue
+	v, err := MarkdownSanitizationArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, v)
+	// argument exist.
+	expected = "strict"
+	r.WithArg(MarkdownSanitizationArgKey, "strict")
+	v, err = MarkdownSanitizationArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, v)
+	// should not be OK as argument value
+	// is more permissive than allowed.
+	expected = defaultValue
+	r.WithArg(MarkdownSanitizationArgKey, "none")
+	v, err = MarkdownSanitizationArg(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, expected, v)
+	// should not be OK as
+	// argument value is invalid.
+	r.WithArg(MarkdownSanitizationArgKey, "foo")
+	v, err = MarkdownSanitizationArg(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, expected, v)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestMarkdownFilesArg(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	logger := test.DebugLogger()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// argument does not exist.
+	v, err := MarkdownFilesArg(r)
+	assert.Nil(t, err)
+	assert.Nil(t, v)
+	// argument exist.
+	for _, filename := range []string{"a.md", "b.md"} {
+		err = r.WithFile(filename, strings.NewReader("# Title"))
+		assert.Nil(t, err)
+	}
+	expected := []string{"b.md", "a.md"}
+	r.WithArg(MarkdownFilesArgKey, `["b.md", "a.md"]`)
+	v, err = MarkdownFilesArg(r)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, v)
+	// should not be OK as argument value
+	// contains a file which does not exist.
+	r.WithArg(MarkdownFilesArgKey, `["c.md"]`)
+	v, err = MarkdownFilesArg(r)
+	test.AssertError(t, err)
+	assert.Nil(t, v)
+	// should not be OK as
+	// argument value is invalid.
+	r.WithArg(MarkdownFilesArgKey, "foo")
+	v, err = MarkdownFilesArg(r)
+	test.AssertError(t, err)
+	assert.Nil(t, v)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestCSVDelimiterArg(t *testing.T) {
+	const (
+		resourceDirectoryName string = "foo"
+		defaultValue          string = ","
+	)
+	var expected string
+	logger := test.DebugLogger()
+	config := conf.DefaultConfig()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// argument does not exist.
+	expected = defaultValue
+	v, err := CSVDelimiterArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, v)
+	// argument exist.
+	expected = ";"
+	r.WithArg(CSVDelimiterArgKey, ";")
+	v, err = CSVDelimiterArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, v)
+	// argument exist with a tab.
+	expected = "\t"
+	r.WithArg(CSVDelimiterArgKey, "\t")
+	v, err = CSVDelimiterArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, v)
+	// should not be OK as argument value
+	// has more than one character.
+	expected = defaultValue
+	r.WithArg(CSVDelimiterArgKey, ";;")
+	v, err = CSVDelimiterArg(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, expected, v)
+	// should not be OK as argument value
+	// is not an ASCII character.
+	r.WithArg(CSVDelimiterArgKey, "é")
+	v, err = CSVDelimiterArg(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, expected, v)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestCSVEncodingArg(t *testing.T) {
+	const (
+		resourceDirectoryName string = "foo"
+		defaultValue          string = "UTF-8"
+	)
+	var expected string
+	logger := test.DebugLogger()
+	config := conf.DefaultConfig()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// argument does not exist.
+	expected = defaultValue
+	v, err := CSVEncodingArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, v)
+	// argument exist.
+	expected = "windows-1252"
+	r.WithArg(CSVEncodingArgKey, "windows-1252")
+	v, err = CSVEncodingArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, v)
+	// should not be OK as
+	// argument value is invalid.
+	expected = defaultValue
+	r.WithArg(CSVEncodingArgKey, "foo")
+	v, err = CSVEncodingArg(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, expected, v)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestOfficeOutputFormatArg(t *testing.T) {
+	const (
+		resourceDirectoryName string = "foo"
+		defaultValue          string = "pdf"
+	)
+	var expected string
+	logger := test.DebugLogger()
+	config := conf.DefaultConfig()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// argument does not exist.
+	expected = defaultValue
+	v, err := OfficeOutputFormatArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, v)
+	// argument exist.
+	expected = "docx"
+	r.WithArg(OutputFormatArgKey, "docx")
+	v, err = OfficeOutputFormatArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, v)
+	// should not be OK as
+	// argument value is invalid.
+	expected = defaultValue
+	r.WithArg(OutputFormatArgKey, "foo")
+	v, err = OfficeOutputFormatArg(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, expected, v)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestFailOnHTTPStatusCodesArg(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	logger := test.DebugLogger()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// argument does not exist.
+	v, err := FailOnHTTPStatusCodesArg(r)
+	assert.Nil(t, err)
+	assert.Nil(t, v)
+	// argument exist.
+	r.WithArg(FailOnHTTPStatusCodesArgKey, "[404, 599]")
+	v, err = FailOnHTTPStatusCodesArg(r)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{404, 599}, v)
+	// should not be OK as argument
+	// value contains a wrong HTTP status code.
+	r.WithArg(FailOnHTTPStatusCodesArgKey, "[404, 600]")
+	v, err = FailOnHTTPStatusCodesArg(r)
+	test.AssertError(t, err)
+	assert.Nil(t, v)
+	// should not be OK as
+	// argument value is invalid.
+	r.WithArg(FailOnHTTPStatusCodesArgKey, "foo")
+	v, err = FailOnHTTPStatusCodesArg(r)
+	test.AssertError(t, err)
+	assert.Nil(t, v)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestCookiesArg(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	logger := test.DebugLogger()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// argument does not exist.
+	v, err := CookiesArg(r)
+	assert.Nil(t, err)
+	assert.Nil(t, v)
+	// argument exist.
+	expected := []printer.Cookie{
+		{Name: "foo", Value: "bar"},
+		{Name: "session", Value: "baz", Domain: "example.com", Path: "/", Secure: true, HTTPOnly: true, SameSite: "Lax"},
+	}
+	r.WithArg(CookiesArgKey, `[
+		{"name": "foo", "value": "bar"},
+		{"name": "session", "value": "baz", "domain": "example.com", "path": "/", "secure": true, "httpOnly": true, "sameSite": "Lax"}
+	]`)
+	v, err = CookiesArg(r)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, v)
+	// should not be OK as argument
+	// value contains a cookie without name.
+	r.WithArg(CookiesArgKey, `[{"value": "bar"}]`)
+	v, err = CookiesArg(r)
+	test.AssertError(t, err)
+	assert.Nil(t, v)
+	// should not be OK as argument
+	// value contains a wrong "sameSite" value.
+	r.WithArg(CookiesArgKey, `[{"name": "foo", "value": "bar", "sameSite": "foo"}]`)
+	v, err = CookiesArg(r)
+	test.AssertError(t, err)
+	assert.Nil(t, v)
+	// should not be OK as
+	// argument value is invalid.
+	r.WithArg(CookiesArgKey, "foo")
+	v, err = CookiesArg(r)
+	test.AssertError(t, err)
+	assert.Nil(t, v)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestViewportArgs(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	var expected int64
+	logger := test.DebugLogger()
+	config := conf.DefaultConfig()
+	opts := printer.DefaultChromePrinterOptions(config)
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// arguments do not exist.
+	width, height, err := ViewportArgs(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, opts.ViewportWidth, width)
+	assert.Equal(t, opts.ViewportHeight, height)
+	// arguments exist.
+	expected = 1280
+	r.WithArg(ViewportWidthArgKey, "1280")
+	r.WithArg(ViewportHeightArgKey, "1280")
+	width, height, err = ViewportArgs(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, width)
+	assert.Equal(t, expected, height)
+	// should not be OK as arguments
+	// value are < 0.
+	expected = opts.ViewportWidth
+	r.WithArg(ViewportWidthArgKey, "-1")
+	width, _, err = ViewportArgs(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, expected, width)
+	r.WithArg(ViewportWidthArgKey, "1280")
+	expected = opts.ViewportHeight
+	r.WithArg(ViewportHeightArgKey, "-1")
+	_, height, err = ViewportArgs(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, expected, height)
+	r.WithArg(ViewportHeightArgKey, "1280")
+	// should not be OK as arguments
+	// value are > 10000.
+	expected = opts.ViewportWidth
+	r.WithArg(ViewportWidthArgKey, "10001")
+	width, _, err = ViewportArgs(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, expected, width)
+	r.WithArg(ViewportWidthArgKey, "1280")
+	// should not be OK as
+	// arguments value are invalids.
+	expected = opts.ViewportHeight
+	r.WithArg(ViewportHeightArgKey, "foo")
+	_, height, err = ViewportArgs(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, expected, height)
+	r.WithArg(ViewportHeightArgKey, "1280")
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestDeviceScaleFactorArg(t *testing.T) {
+	const (
+		resourceDirectoryName string  = "foo"
+		defaultValue          float64 = 1.0
+	)
+	var expected float64
+	logger := test.DebugLogger()
+	config := conf.DefaultConfig()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// argument does not exist.
+	expected = defaultValue
+	v, err := DeviceScaleFactorArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, v)
+	// argument exist.
+	expected = 2.0
+	r.WithArg(DeviceScaleFactorArgKey, "2.0")
+	v, err = DeviceScaleFactorArg(r, config)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, v)
+	// should not be OK as argument
+	// value is < 0.1.
+	expected = defaultValue
+	r.WithArg(DeviceScaleFactorArgKey, "0.0")
+	v, err = DeviceScaleFactorArg(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, expected, v)
+	// should not be OK as argument
+	// value is > 10.0.
+	expected = defaultValue
+	r.WithArg(DeviceScaleFactorArgKey, "10.1")
+	v, err = DeviceScaleFactorArg(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, expected, v)
+	// should not be OK as
+	// argument value is invalid.
+	expected = defaultValue
+	r.WithArg(DeviceScaleFactorArgKey, "foo")
+	v, err = DeviceScaleFactorArg(r, config)
+	test.AssertError(t, err)
+	assert.Equal(t, expected, v)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestURLPatternsArgs(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	logger := test.DebugLogger()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// arguments do not exist.
+	allowed, blocked, err := URLPatternsArgs(r)
+	assert.Nil(t, err)
+	assert.Nil(t, allowed)
+	assert.Nil(t, blocked)
+	// arguments exist.
+	r.WithArg(AllowedURLPatternsArgKey, `["https://example.com/*"]`)
+	r.WithArg(BlockedURLPatternsArgKey, `["*://*.doubleclick.net/*", "*.mp4"]`)
+	allowed, blocked, err = URLPatternsArgs(r)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"https://example.com/*"}, allowed)
+	assert.Equal(t, []string{"*://*.doubleclick.net/*", "*.mp4"}, blocked)
+	// should not be OK as
+	// arguments value are invalids.
+	r.WithArg(AllowedURLPatternsArgKey, "foo")
+	_, _, err = URLPatternsArgs(r)
+	test.AssertError(t, err)
+	r.WithArg(AllowedURLPatternsArgKey, `["https://example.com/*"]`)
+	r.WithArg(BlockedURLPatternsArgKey, "[1]")
+	_, _, err = URLPatternsArgs(r)
+	test.AssertError(t, err)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestPDFExportArgs(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	logger := test.DebugLogger()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// arguments do not exist.
+	expected := printer.DefaultPDFExportOptions()
+	v, err := PDFExportArgs(r)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, v)
+	// only the given arguments are set.
+	quality := int64(50)
+	expected = printer.PDFExportOptions{Quality: &quality}
+	r.WithArg(QualityArgKey, "50")
+	v, err = PDFExportArgs(r)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, v)
+	// arguments exist.
+	enabled, disabled := true, false
+	maxImageResolution := int64(150)
+	expected = printer.PDFExportOptions{
+		Quality:                  &quality,
+		ReduceImageResolution:    &enabled,
+		MaxImageResolution:       &maxImageResolution,
+		LosslessImageCompression: &enabled,
+		ExportBookmarks:          &disabled,
+		ExportNotes:              &enabled,
+		ExportNotesPages:         &enabled,
+		ExportFormFields:         &disabled,
+		SkipEmptyPages:           &enabled,
+	}
+	r.WithArg(QualityArgKey, "50")
+	r.WithArg(ReduceImageResolutionArgKey, "true")
+	r.WithArg(MaxImageResolutionArgKey, "150")
+	r.WithArg(LosslessImageCompressionArgKey, "true")
+	r.WithArg(ExportBookmarksArgKey, "false")
+	r.WithArg(ExportNotesArgKey, "true")
+	r.WithArg(ExportNotesPagesArgKey, "true")
+	r.WithArg(ExportFormFieldsArgKey, "false")
+	r.WithArg(SkipEmptyPagesArgKey, "true")
+	v, err = PDFExportArgs(r)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, v)
+	// should not be OK as argument
+	// "quality" value is > 100.
+	r.WithArg(QualityArgKey, "101")
+	_, err = PDFExportArgs(r)
+	test.AssertError(t, err)
+	r.WithArg(QualityArgKey, "50")
+	// should not be OK as argument
+	// "maxImageResolution" value is invalid.
+	r.WithArg(MaxImageResolutionArgKey, "100")
+	_, err = PDFExportArgs(r)
+	test.AssertError(t, err)
+	r.WithArg(MaxImageResolutionArgKey, "150")
+	// should not be OK as argument
+	// "exportNotes" value is invalid.
+	r.WithArg(ExportNotesArgKey, "foo")
+	_, err = PDFExportArgs(r)
+	test.AssertError(t, err)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestStampArgs(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	logger := test.DebugLogger()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// arguments do not exist.
+	expected := printer.DefaultStampOptions()
+	v, err := StampArgs(r)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, v)
+	// arguments exist.
+	expected = printer.StampOptions{
+		Format:      "ACME-{page:6}",
+		StartNumber: 42,
+		Position:    "top-right",
+		Font:        "Courier",
+		FontSize:    8.0,
+	}
+	r.WithArg(StampFormatArgKey, "ACME-{page:6}")
+	r.WithArg(StampStartNumberArgKey, "42")
+	r.WithArg(StampPositionArgKey, "top-right")
+	r.WithArg(StampFontArgKey, "Courier")
+	r.WithArg(StampFontSizeArgKey, "8")
+	v, err = StampArgs(r)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, v)
+	// should not be OK as argument
+	// "stampStartNumber" value is < 0.
+	r.WithArg(StampStartNumberArgKey, "-1")
+	_, err = StampArgs(r)
+	test.AssertError(t, err)
+	r.WithArg(StampStartNumberArgKey, "42")
+	// should not be OK as argument
+	// "stampPosition" value is invalid.
+	r.WithArg(StampPositionArgKey, "middle")
+	_, err = StampArgs(r)
+	test.AssertError(t, err)
+	r.WithArg(StampPositionArgKey, "top-right")
+	// should not be OK as argument
+	// "stampFont" value is invalid.
+	r.WithArg(StampFontArgKey, "Comic Sans")
+	_, err = StampArgs(r)
+	test.AssertError(t, err)
+	r.WithArg(StampFontArgKey, "Courier")
+	// should not be OK as argument
+	// "stampFontSize" value is > 72.
+	r.WithArg(StampFontSizeArgKey, "73")
+	_, err = StampArgs(r)
+	test.AssertError(t, err)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
+
+func TestTemplateDataArg(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	logger := test.DebugLogger()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// argument and file do not exist.
+	data, ok, err := TemplateDataArg(r)
+	assert.Nil(t, err)
+	assert.Equal(t, false, ok)
+	assert.Nil(t, data)
+	// file exists.
+	err = r.WithFile("data.json", strings.NewReader(`{"total": 1234.50}`))
+	assert.Nil(t, err)
+	data, ok, err = TemplateDataArg(r)
+	assert.Nil(t, err)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, map[string]interface{}{"total": json.Number("1234.50")}, data)
+	// argument exists.
+	r.WithArg(DataArgKey, `{"customer": {"name": "Gutenberg"}}`)
+	data, ok, err = TemplateDataArg(r)
+	assert.Nil(t, err)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, map[string]interface{}{"customer": map[string]interface{}{"name": "Gutenberg"}}, data)
+	// should not be OK as
+	// argument value is invalid.
+	r.WithArg(DataArgKey, "{foo")
+	_, _, err = TemplateDataArg(r)
+	test.AssertError(t, err)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
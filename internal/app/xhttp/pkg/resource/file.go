@@ -57,6 +57,9 @@ func (f file) content() (string, error) {
 HeaderFooterContents is a helper for retrieving
 the content of the files "header.html"
 and "footer.html".
+
+The images and stylesheets of the resource
+are inlined in these contents.
 */
 func HeaderFooterContents(r Resource, config conf.Config) (string, string, error) {
 	const op string = "resource.HeaderFooterContents"
@@ -74,6 +77,18 @@ func HeaderFooterContents(r Resource, config conf.Config) (string, string, error
 				opts.FooterHTML,
 				err
 		}
+		headerHTML, err = inlineAssets(r, headerHTML)
+		if err != nil {
+			return opts.HeaderHTML,
+				opts.FooterHTML,
+				err
+		}
+		footerHTML, err = inlineAssets(r, footerHTML)
+		if err != nil {
+			return opts.HeaderHTML,
+				opts.FooterHTML,
+				err
+		}
 		return headerHTML,
 			footerHTML,
 			nil
@@ -89,3 +104,43 @@ func HeaderFooterContents(r Resource, config conf.Config) (string, string, error
 		footerHTML,
 		nil
 }
+
+/*
+HeaderFooterVariantsContents is a helper for
+retrieving the content of the files "header-first.html",
+"header-odd.html", "header-even.html", "footer-first.html",
+"footer-odd.html" and "footer-even.html".
+
+The images and stylesheets of the resource
+are inlined in these contents.
+*/
+func HeaderFooterVariantsContents(r Resource) (printer.HeaderFooterVariants, error) {
+	const op string = "resource.HeaderFooterVariantsContents"
+	resolver := func() (printer.HeaderFooterVariants, error) {
+		var variants printer.HeaderFooterVariants
+		for filename, content := range map[string]*string{
+			"header-first.html": &variants.FirstHeaderHTML,
+			"header-odd.html":   &variants.OddHeaderHTML,
+			"header-even.html":  &variants.EvenHeaderHTML,
+			"footer-first.html": &variants.FirstFooterHTML,
+			"footer-odd.html":   &variants.OddFooterHTML,
+			"footer-even.html":  &variants.EvenFooterHTML,
+		} {
+			html, err := r.Fcontent(filename, "")
+			if err != nil {
+				return printer.HeaderFooterVariants{}, err
+			}
+			html, err = inlineAssets(r, html)
+			if err != nil {
+				return printer.HeaderFooterVariants{}, err
+			}
+			*content = html
+		}
+		return variants, nil
+	}
+	result, err := resolver()
+	if err != nil {
+		return result, xerror.New(op, err)
+	}
+	return result, nil
+}
@@ -2,6 +2,7 @@ package resource
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -44,3 +45,33 @@ func TestHeaderFooterContents(t *testing.T) {
 	err = r.Close()
 	assert.Nil(t, err)
 }
+
+func TestHeaderFooterVariantsContents(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	logger := test.DebugLogger()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	// files do not exist.
+	variants, err := HeaderFooterVariantsContents(r)
+	assert.Nil(t, err)
+	assert.Equal(t, printer.HeaderFooterVariants{}, variants)
+	// files exist.
+	for _, fpath := range test.VariantFpaths(t) {
+		f, err := os.Open(fpath)
+		assert.Nil(t, err)
+		defer f.Close() // nolint: errcheck
+		err = r.WithFile(filepath.Base(fpath), f)
+		assert.Nil(t, err)
+	}
+	variants, err = HeaderFooterVariantsContents(r)
+	assert.Nil(t, err)
+	assert.Contains(t, variants.FirstHeaderHTML, "first page header")
+	assert.Contains(t, variants.OddHeaderHTML, "odd page header")
+	assert.Contains(t, variants.EvenHeaderHTML, "even page header")
+	assert.Contains(t, variants.FirstFooterHTML, "first page footer")
+	assert.Contains(t, variants.OddFooterHTML, "odd page footer")
+	assert.Contains(t, variants.EvenFooterHTML, "even page footer")
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
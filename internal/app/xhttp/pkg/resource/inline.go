@@ -0,0 +1,126 @@
+package resource
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+)
+
+// nolint: gochecknoglobals
+var (
+	imgSrcRegexp  = regexp.MustCompile(`(?i)(<img\b[^>]*?\bsrc\s*=\s*)(["'])([^"']*)(["'])`)
+	linkRegexp    = regexp.MustCompile(`(?i)<link\b[^>]*>`)
+	linkRelRegexp = regexp.MustCompile(`(?i)\brel\s*=\s*["']?stylesheet\b`)
+	hrefRegexp    = regexp.MustCompile(`(?i)\bhref\s*=\s*(["'])([^"']*)(["'])`)
+	cssURLRegexp  = regexp.MustCompile(`(?i)url\(\s*(["']?)([^"')]*)(["']?)\s*\)`)
+)
+
+/*
+inlineAssets replaces the references to the
+files of the resource in given HTML: images
+and CSS urls (fonts, images) become data URIs,
+linked stylesheets become style elements.
+
+Google Chrome cannot load those files from
+the header and footer templates.
+References to other files are left untouched.
+*/
+func inlineAssets(r Resource, html string) (string, error) {
+	const op string = "resource.inlineAssets"
+	var errs []error
+	result := linkRegexp.ReplaceAllStringFunc(html, func(link string) string {
+		if !linkRelRegexp.MatchString(link) {
+			return link
+		}
+		matches := hrefRegexp.FindStringSubmatch(link)
+		if matches == nil {
+			return link
+		}
+		file, ok := r.assetFile(".", matches[2])
+		if !ok {
+			return link
+		}
+		content, err := file.content()
+		if err != nil {
+			errs = append(errs, err)
+			return link
+		}
+		// the urls of a stylesheet are relative
+		// to its own directory.
+		dir := path.Dir(path.Clean(strings.TrimSpace(matches[2])))
+		return fmt.Sprintf("<style>%s</style>", r.inlineCSSURLs(dir, content, &errs))
+	})
+	result = imgSrcRegexp.ReplaceAllStringFunc(result, func(img string) string {
+		matches := imgSrcRegexp.FindStringSubmatch(img)
+		dataURI, ok, err := r.dataURI(".", matches[3])
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if !ok {
+			return img
+		}
+		return fmt.Sprintf("%s%s%s%s", matches[1], matches[2], dataURI, matches[4])
+	})
+	result = r.inlineCSSURLs(".", result, &errs)
+	if len(errs) > 0 {
+		return html, xerror.New(op, errs[0])
+	}
+	return result, nil
+}
+
+// inlineCSSURLs replaces the CSS urls of given
+// content, relative to given directory, with
+// data URIs.
+func (r Resource) inlineCSSURLs(dir, content string, errs *[]error) string {
+	return cssURLRegexp.ReplaceAllStringFunc(content, func(url string) string {
+		matches := cssURLRegexp.FindStringSubmatch(url)
+		dataURI, ok, err := r.dataURI(dir, matches[2])
+		if err != nil {
+			*errs = append(*errs, err)
+		}
+		if !ok {
+			return url
+		}
+		return fmt.Sprintf("url('%s')", dataURI)
+	})
+}
+
+// assetFile returns the file of the resource
+// targeted by given reference, relative to
+// given directory, if any.
+func (r Resource) assetFile(dir, ref string) (file, bool) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || strings.HasPrefix(ref, "/") || strings.Contains(ref, ":") {
+		return file{}, false
+	}
+	// files from archives are identified by their relative path.
+	f, ok := r.files[path.Join(dir, ref)]
+	return f, ok
+}
+
+// dataURI returns the data URI of the file of
+// the resource targeted by given reference,
+// relative to given directory, if any.
+func (r Resource) dataURI(dir, ref string) (string, bool, error) {
+	f, ok := r.assetFile(dir, ref)
+	if !ok {
+		return "", false, nil
+	}
+	b, err := ioutil.ReadFile(f.fpath)
+	if err != nil {
+		return "", false, err
+	}
+	mimeType := mime.TypeByExtension(filepath.Ext(f.fpath))
+	if mimeType == "" {
+		mimeType = http.DetectContentType(b)
+	}
+	return fmt.Sprintf("data:%s;base64,%s", mimeType, base64.StdEncoding.EncodeToString(b)), true, nil
+}
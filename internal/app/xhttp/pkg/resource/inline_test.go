@@ -0,0 +1,61 @@
+package resource
+
+import (
+	"mime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thecodingmachine/gotenberg/test"
+)
+
+func TestInlineAssets(t *testing.T) {
+	const resourceDirectoryName string = "foo"
+	logger := test.DebugLogger()
+	r, err := New(logger, resourceDirectoryName)
+	assert.Nil(t, err)
+	err = r.WithFile("style.css", strings.NewReader("@font-face { src: url('font.woff'); }"))
+	assert.Nil(t, err)
+	err = r.WithFile("font.woff", strings.NewReader("foo"))
+	assert.Nil(t, err)
+	err = r.WithFile("img.gif", strings.NewReader("bar"))
+	assert.Nil(t, err)
+	// no references to resource files.
+	html := `<html><head><link rel="stylesheet" href="https://example.com/style.css"></head><body><img src="https://example.com/img.gif"></body></html>`
+	result, err := inlineAssets(r, html)
+	assert.Nil(t, err)
+	assert.Equal(t, html, result)
+	// references to resource files.
+	html = `<html><head><link rel="stylesheet" type="text/css" href="style.css"></head>` +
+		`<body style="background: url(./img.gif)"><img class="logo" src='img.gif'><img src="../img.gif"></body></html>`
+	expected := `<html><head><style>@font-face { src: url('data:` + mime.TypeByExtension(".woff") + `;base64,Zm9v'); }</style></head>` +
+		`<body style="background: url('data:image/gif;base64,YmFy')"><img class="logo" src='data:image/gif;base64,YmFy'><img src="../img.gif"></body></html>`
+	result, err = inlineAssets(r, html)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, result)
+	// references to resource files from an archive.
+	err = r.WithFile("site.zip", archive(
+		t,
+		archiveEntry{name: "about.html", content: "<html></html>"},
+		archiveEntry{name: "img/logo.gif", content: "baz"},
+		archiveEntry{name: "css/header.css", content: ".logo { background: url(\"../img/logo.gif\"); }"},
+	))
+	assert.Nil(t, err)
+	err = r.ExtractArchives()
+	assert.Nil(t, err)
+	html = `<img src="img/logo.gif"><img src="./img/../img/logo.gif"><img src="/img/logo.gif">`
+	expected = `<img src="data:image/gif;base64,YmF6"><img src="data:image/gif;base64,YmF6"><img src="/img/logo.gif">`
+	result, err = inlineAssets(r, html)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, result)
+	// a nested stylesheet, with urls
+	// relative to its own directory.
+	html = `<link rel="stylesheet" href="css/header.css">`
+	expected = `<style>.logo { background: url('data:image/gif;base64,YmF6'); }</style>`
+	result, err = inlineAssets(r, html)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, result)
+	// finally...
+	err = r.Close()
+	assert.Nil(t, err)
+}
@@ -196,6 +196,13 @@ func (r Resource) BoolArg(key ArgKey, defaultValue bool) (bool, error) {
 	return result, nil
 }
 
+// HasFile returns true if given
+// filename exists among the Resource.
+func (r Resource) HasFile(filename string) bool {
+	_, ok := r.files[filename]
+	return ok
+}
+
 // Fpath returns the path of the given filename.
 // This filename should exist whithin the Resource.
 func (r Resource) Fpath(filename string) (string, error) {
@@ -200,10 +200,12 @@ func TestFpath(t *testing.T) {
 	v, err := r.Fpath(filename)
 	assert.Nil(t, err)
 	assert.Equal(t, expected, v)
+	assert.Equal(t, true, r.HasFile(filename))
 	// should not be OK as file does
 	// not exist.
 	_, err = r.Fpath("bar.pdf")
 	test.AssertError(t, err)
+	assert.Equal(t, false, r.HasFile("bar.pdf"))
 	// finally...
 	err = r.Close()
 	assert.Nil(t, err)
@@ -1,6 +1,8 @@
 package conf
 
 import (
+	"strings"
+
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xassert"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
@@ -43,43 +45,72 @@ const (
 	// GoogleChromeIgnoreCertificateErrorsEnvVar contains the name
 	// of the environment variable "GOOGLE_CHROME_IGNORE_CERTIFICATE_ERRORS".
 	GoogleChromeIgnoreCertificateErrorsEnvVar string = "GOOGLE_CHROME_IGNORE_CERTIFICATE_ERRORS"
+	// GoogleChromeAllowedURLPatternsEnvVar contains the name
+	// of the environment variable "GOOGLE_CHROME_ALLOWED_URL_PATTERNS".
+	GoogleChromeAllowedURLPatternsEnvVar string = "GOOGLE_CHROME_ALLOWED_URL_PATTERNS"
+	// GoogleChromeBlockedURLPatternsEnvVar contains the name
+	// of the environment variable "GOOGLE_CHROME_BLOCKED_URL_PATTERNS".
+	GoogleChromeBlockedURLPatternsEnvVar string = "GOOGLE_CHROME_BLOCKED_URL_PATTERNS"
+	// DefaultMarkdownSanitizationProfileEnvVar contains the name
+	// of the environment variable "DEFAULT_MARKDOWN_SANITIZATION_PROFILE".
+	DefaultMarkdownSanitizationProfileEnvVar string = "DEFAULT_MARKDOWN_SANITIZATION_PROFILE"
+	// MostPermissiveMarkdownSanitizationProfileEnvVar contains the name
+	// of the environment variable "MOST_PERMISSIVE_MARKDOWN_SANITIZATION_PROFILE".
+	MostPermissiveMarkdownSanitizationProfileEnvVar string = "MOST_PERMISSIVE_MARKDOWN_SANITIZATION_PROFILE"
 )
 
+/*
+MarkdownSanitizationProfiles returns the
+sanitization profiles of the HTML from Markdown
+files, from the strictest to the most permissive.
+*/
+func MarkdownSanitizationProfiles() []string {
+	return []string{"strict", "ugc", "trusted", "none"}
+}
+
 // Config contains the application
 // configuration.
 type Config struct {
-	maximumWaitTimeout                  float64
-	maximumWaitDelay                    float64
-	maximumWebhookURLTimeout            float64
-	defaultWaitTimeout                  float64
-	defaultWebhookURLTimeout            float64
-	defaultListenPort                   int64
-	disableGoogleChrome                 bool
-	disableUnoconv                      bool
-	googleChromeIgnoreCertificateErrors bool
-	logLevel                            xlog.Level
-	rootPath                            string
-	maximumGoogleChromeRpccBufferSize   int64
-	defaultGoogleChromeRpccBufferSize   int64
+	maximumWaitTimeout                        float64
+	maximumWaitDelay                          float64
+	maximumWebhookURLTimeout                  float64
+	defaultWaitTimeout                        float64
+	defaultWebhookURLTimeout                  float64
+	defaultListenPort                         int64
+	disableGoogleChrome                       bool
+	disableUnoconv                            bool
+	googleChromeIgnoreCertificateErrors       bool
+	logLevel                                  xlog.Level
+	rootPath                                  string
+	maximumGoogleChromeRpccBufferSize         int64
+	defaultGoogleChromeRpccBufferSize         int64
+	googleChromeAllowedURLPatterns            []string
+	googleChromeBlockedURLPatterns            []string
+	defaultMarkdownSanitizationProfile        string
+	mostPermissiveMarkdownSanitizationProfile string
 }
 
 // DefaultConfig returns the default
 // configuration.
 func DefaultConfig() Config {
 	return Config{
-		maximumWaitTimeout:                  30.0,
-		maximumWaitDelay:                    10.0,
-		maximumWebhookURLTimeout:            30.0,
-		defaultWaitTimeout:                  10.0,
-		defaultWebhookURLTimeout:            10.0,
-		defaultListenPort:                   3000,
-		disableGoogleChrome:                 false,
-		disableUnoconv:                      false,
-		logLevel:                            xlog.InfoLevel,
-		rootPath:                            "/",
-		maximumGoogleChromeRpccBufferSize:   104857600, // ~100 MB
-		defaultGoogleChromeRpccBufferSize:   1048576,   // 1 MB
-		googleChromeIgnoreCertificateErrors: false,
+		maximumWaitTimeout:                        30.0,
+		maximumWaitDelay:                          10.0,
+		maximumWebhookURLTimeout:                  30.0,
+		defaultWaitTimeout:                        10.0,
+		defaultWebhookURLTimeout:                  10.0,
+		defaultListenPort:                         3000,
+		disableGoogleChrome:                       false,
+		disableUnoconv:                            false,
+		logLevel:                                  xlog.InfoLevel,
+		rootPath:                                  "/",
+		maximumGoogleChromeRpccBufferSize:         104857600, // ~100 MB
+		defaultGoogleChromeRpccBufferSize:         1048576,   // 1 MB
+		googleChromeIgnoreCertificateErrors:       false,
+		googleChromeAllowedURLPatterns:            nil,
+		googleChromeBlockedURLPatterns:            nil,
+		defaultMarkdownSanitizationProfile:        "ugc",
+		mostPermissiveMarkdownSanitizationProfile: "ugc",
 	}
 }
 
@@ -201,6 +232,40 @@ func FromEnv() (Config, error) {
 		if err != nil {
 			return c, err
 		}
+		googleChromeAllowedURLPatterns, err := xassert.StringFromEnv(
+			GoogleChromeAllowedURLPatternsEnvVar,
+			"",
+		)
+		if err != nil {
+			return c, err
+		}
+		c.googleChromeAllowedURLPatterns = splitURLPatterns(googleChromeAllowedURLPatterns)
+		googleChromeBlockedURLPatterns, err := xassert.StringFromEnv(
+			GoogleChromeBlockedURLPatternsEnvVar,
+			"",
+		)
+		if err != nil {
+			return c, err
+		}
+		c.googleChromeBlockedURLPatterns = splitURLPatterns(googleChromeBlockedURLPatterns)
+		mostPermissiveMarkdownSanitizationProfile, err := xassert.StringFromEnv(
+			MostPermissiveMarkdownSanitizationProfileEnvVar,
+			c.mostPermissiveMarkdownSanitizationProfile,
+			xassert.StringOneOf(MarkdownSanitizationProfiles()),
+		)
+		if err != nil {
+			return c, err
+		}
+		c.mostPermissiveMarkdownSanitizationProfile = mostPermissiveMarkdownSanitizationProfile
+		defaultMarkdownSanitizationProfile, err := xassert.StringFromEnv(
+			DefaultMarkdownSanitizationProfileEnvVar,
+			c.defaultMarkdownSanitizationProfile,
+			xassert.StringOneOf(c.AllowedMarkdownSanitizationProfiles()),
+		)
+		if err != nil {
+			return c, err
+		}
+		c.defaultMarkdownSanitizationProfile = defaultMarkdownSanitizationProfile
 		return c, nil
 	}
 	result, err := resolver()
@@ -210,6 +275,19 @@ func FromEnv() (Config, error) {
 	return result, nil
 }
 
+// splitURLPatterns splits a comma-separated
+// list of URL patterns.
+func splitURLPatterns(value string) []string {
+	var patterns []string
+	for _, pattern := range strings.Split(value, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
 // MaximumWaitTimeout returns the maximum
 // wait timeout from the configuration.
 func (c Config) MaximumWaitTimeout() float64 {
@@ -291,3 +369,44 @@ func (c Config) DefaultGoogleChromeRpccBufferSize() int64 {
 func (c Config) GoogleChromeIgnoreCertificateErrors() bool {
 	return c.googleChromeIgnoreCertificateErrors
 }
+
+// GoogleChromeAllowedURLPatterns returns the URL patterns
+// Google Chrome may request from the configuration.
+func (c Config) GoogleChromeAllowedURLPatterns() []string {
+	return c.googleChromeAllowedURLPatterns
+}
+
+// GoogleChromeBlockedURLPatterns returns the URL patterns
+// Google Chrome may not request from the configuration.
+func (c Config) GoogleChromeBlockedURLPatterns() []string {
+	return c.googleChromeBlockedURLPatterns
+}
+
+// DefaultMarkdownSanitizationProfile returns the default
+// Markdown sanitization profile from the configuration.
+func (c Config) DefaultMarkdownSanitizationProfile() string {
+	return c.defaultMarkdownSanitizationProfile
+}
+
+// MostPermissiveMarkdownSanitizationProfile returns the most permissive
+// Markdown sanitization profile from the configuration.
+func (c Config) MostPermissiveMarkdownSanitizationProfile() string {
+	return c.mostPermissiveMarkdownSanitizationProfile
+}
+
+/*
+AllowedMarkdownSanitizationProfiles returns the
+Markdown sanitization profiles which are not more
+permissive than the most permissive one from the
+configuration.
+*/
+func (c Config) AllowedMarkdownSanitizationProfiles() []string {
+	var profiles []string
+	for _, profile := range MarkdownSanitizationProfiles() {
+		profiles = append(profiles, profile)
+		if profile == c.mostPermissiveMarkdownSanitizationProfile {
+			break
+		}
+	}
+	return profiles
+}
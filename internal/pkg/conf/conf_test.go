@@ -417,6 +417,77 @@ func TestGoogleChromeIgnoreCertificateErrorsFromEnv(t *testing.T) {
 	assert.Equal(t, expected, result)
 }
 
+func TestGoogleChromeURLPatternsFromEnv(t *testing.T) {
+	var (
+		expected Config
+		result   Config
+		err      error
+	)
+	// GOOGLE_CHROME_ALLOWED_URL_PATTERNS correctly set.
+	os.Setenv(GoogleChromeAllowedURLPatternsEnvVar, "file://*, https://example.com/*")
+	expected = DefaultConfig()
+	expected.googleChromeAllowedURLPatterns = []string{"file://*", "https://example.com/*"}
+	result, err = FromEnv()
+	assert.Nil(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(GoogleChromeAllowedURLPatternsEnvVar)
+	// GOOGLE_CHROME_BLOCKED_URL_PATTERNS correctly set.
+	os.Setenv(GoogleChromeBlockedURLPatternsEnvVar, "*://*.doubleclick.net/*,,")
+	expected = DefaultConfig()
+	expected.googleChromeBlockedURLPatterns = []string{"*://*.doubleclick.net/*"}
+	result, err = FromEnv()
+	assert.Nil(t, err)
+	assert.Equal(t, expected, result)
+	os.Unsetenv(GoogleChromeBlockedURLPatternsEnvVar)
+}
+
+func TestMarkdownSanitizationProfileFromEnv(t *testing.T) {
+	var (
+		expected Config
+		result   Config
+		err      error
+	)
+	// MOST_PERMISSIVE_MARKDOWN_SANITIZATION_PROFILE
+	// and DEFAULT_MARKDOWN_SANITIZATION_PROFILE correctly set.
+	os.Setenv(MostPermissiveMarkdownSanitizationProfileEnvVar, "none")
+	os.Setenv(DefaultMarkdownSanitizationProfileEnvVar, "trusted")
+	expected = DefaultConfig()
+	expected.mostPermissiveMarkdownSanitizationProfile = "none"
+	expected.defaultMarkdownSanitizationProfile = "trusted"
+	result, err = FromEnv()
+	assert.Nil(t, err)
+	assert.Equal(t, expected, result)
+	assert.Equal(t, []string{"strict", "ugc", "trusted", "none"}, result.AllowedMarkdownSanitizationProfiles())
+	os.Unsetenv(MostPermissiveMarkdownSanitizationProfileEnvVar)
+	os.Unsetenv(DefaultMarkdownSanitizationProfileEnvVar)
+	// MOST_PERMISSIVE_MARKDOWN_SANITIZATION_PROFILE
+	// stricter than the default profile.
+	os.Setenv(MostPermissiveMarkdownSanitizationProfileEnvVar, "strict")
+	os.Setenv(DefaultMarkdownSanitizationProfileEnvVar, "strict")
+	expected = DefaultConfig()
+	expected.mostPermissiveMarkdownSanitizationProfile = "strict"
+	expected.defaultMarkdownSanitizationProfile = "strict"
+	result, err = FromEnv()
+	assert.Nil(t, err)
+	assert.Equal(t, expected, result)
+	assert.Equal(t, []string{"strict"}, result.AllowedMarkdownSanitizationProfiles())
+	os.Unsetenv(DefaultMarkdownSanitizationProfileEnvVar)
+	_, err = FromEnv()
+	test.AssertError(t, err)
+	os.Unsetenv(MostPermissiveMarkdownSanitizationProfileEnvVar)
+	// DEFAULT_MARKDOWN_SANITIZATION_PROFILE
+	// more permissive than the most permissive profile.
+	os.Setenv(DefaultMarkdownSanitizationProfileEnvVar, "none")
+	_, err = FromEnv()
+	test.AssertError(t, err)
+	os.Unsetenv(DefaultMarkdownSanitizationProfileEnvVar)
+	// MOST_PERMISSIVE_MARKDOWN_SANITIZATION_PROFILE wrongly set.
+	os.Setenv(MostPermissiveMarkdownSanitizationProfileEnvVar, "foo")
+	_, err = FromEnv()
+	test.AssertError(t, err)
+	os.Unsetenv(MostPermissiveMarkdownSanitizationProfileEnvVar)
+}
+
 func TestGetters(t *testing.T) {
 	result := DefaultConfig()
 	assert.Equal(t, result.maximumWaitTimeout, result.MaximumWaitTimeout())
@@ -432,4 +503,8 @@ func TestGetters(t *testing.T) {
 	assert.Equal(t, result.maximumGoogleChromeRpccBufferSize, result.MaximumGoogleChromeRpccBufferSize())
 	assert.Equal(t, result.defaultGoogleChromeRpccBufferSize, result.DefaultGoogleChromeRpccBufferSize())
 	assert.Equal(t, result.googleChromeIgnoreCertificateErrors, result.GoogleChromeIgnoreCertificateErrors())
+	assert.Equal(t, result.googleChromeAllowedURLPatterns, result.GoogleChromeAllowedURLPatterns())
+	assert.Equal(t, result.googleChromeBlockedURLPatterns, result.GoogleChromeBlockedURLPatterns())
+	assert.Equal(t, result.defaultMarkdownSanitizationProfile, result.DefaultMarkdownSanitizationProfile())
+	assert.Equal(t, result.mostPermissiveMarkdownSanitizationProfile, result.MostPermissiveMarkdownSanitizationProfile())
 }
@@ -10,8 +10,11 @@ import (
 
 	"github.com/mafredri/cdp"
 	"github.com/mafredri/cdp/devtool"
+	"github.com/mafredri/cdp/protocol/emulation"
+	"github.com/mafredri/cdp/protocol/fetch"
 	"github.com/mafredri/cdp/protocol/network"
 	"github.com/mafredri/cdp/protocol/page"
+	"github.com/mafredri/cdp/protocol/runtime"
 	"github.com/mafredri/cdp/protocol/target"
 	"github.com/mafredri/cdp/rpcc"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
@@ -23,29 +26,74 @@ import (
 )
 
 type chromePrinter struct {
-	logger xlog.Logger
-	url    string
-	opts   ChromePrinterOptions
+	logger   xlog.Logger
+	url      string
+	opts     ChromePrinterOptions
+	console  *consoleRecorder
+	requests *networkRecorder
 }
 
 // ChromePrinterOptions helps customizing the
 // Google Chrome Printer behaviour.
 type ChromePrinterOptions struct {
-	WaitTimeout       float64
-	WaitDelay         float64
-	HeaderHTML        string
-	FooterHTML        string
-	PaperWidth        float64
-	PaperHeight       float64
-	MarginTop         float64
-	MarginBottom      float64
-	MarginLeft        float64
-	MarginRight       float64
-	Landscape         bool
-	PageRanges        string
-	RpccBufferSize    int64
-	CustomHTTPHeaders map[string]string
-	Scale             float64
+	WaitTimeout                 float64
+	WaitDelay                   float64
+	HeaderHTML                  string
+	FooterHTML                  string
+	PaperWidth                  float64
+	PaperHeight                 float64
+	MarginTop                   float64
+	MarginBottom                float64
+	MarginLeft                  float64
+	MarginRight                 float64
+	Landscape                   bool
+	PageRanges                  string
+	RpccBufferSize              int64
+	CustomHTTPHeaders           map[string]string
+	Scale                       float64
+	EmulatedMediaType           string
+	PreferCSSPageSize           bool
+	WaitForExpression           string
+	WaitForSelector             string
+	FailOnConsoleExceptions     bool
+	FailOnHTTPStatusCodes       []int64
+	FailOnResourceLoadingFailed bool
+	Cookies                     []Cookie
+	UserAgent                   string
+	ViewportWidth               int64
+	ViewportHeight              int64
+	DeviceScaleFactor           float64
+	IsMobile                    bool
+	Timezone                    string
+	Locale                      string
+	AllowedURLPatterns          []string
+	BlockedURLPatterns          []string
+	GlobalAllowedURLPatterns    []string
+	GlobalBlockedURLPatterns    []string
+	RestrictToDocumentOrigin    bool
+	DisableJavaScript           bool
+	GenerateTaggedPDF           bool
+	GenerateDocumentOutline     bool
+	HeaderFooterVariants        HeaderFooterVariants
+	TableOfContents             bool
+	Metadata                    map[string]string
+}
+
+/*
+Cookie is a cookie to set in the browser context
+before loading the page.
+
+If the domain is empty, the cookie is associated
+with the URL of the page.
+*/
+type Cookie struct {
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Domain   string `json:"domain,omitempty"`
+	Path     string `json:"path,omitempty"`
+	Secure   bool   `json:"secure,omitempty"`
+	HTTPOnly bool   `json:"httpOnly,omitempty"`
+	SameSite string `json:"sameSite,omitempty"`
 }
 
 // DefaultChromePrinterOptions returns the default
@@ -53,21 +101,68 @@ type ChromePrinterOptions struct {
 func DefaultChromePrinterOptions(config conf.Config) ChromePrinterOptions {
 	const defaultHeaderFooterHTML string = "<html><head></head><body></body></html>"
 	return ChromePrinterOptions{
-		WaitTimeout:       config.DefaultWaitTimeout(),
-		WaitDelay:         0.0,
-		HeaderHTML:        defaultHeaderFooterHTML,
-		FooterHTML:        defaultHeaderFooterHTML,
-		PaperWidth:        8.27,
-		PaperHeight:       11.7,
-		MarginTop:         1.0,
-		MarginBottom:      1.0,
-		MarginLeft:        1.0,
-		MarginRight:       1.0,
-		Landscape:         false,
-		PageRanges:        "",
-		RpccBufferSize:    config.DefaultGoogleChromeRpccBufferSize(),
-		CustomHTTPHeaders: make(map[string]string),
-		Scale:             1.0,
+		WaitTimeout:                 config.DefaultWaitTimeout(),
+		WaitDelay:                   0.0,
+		HeaderHTML:                  defaultHeaderFooterHTML,
+		FooterHTML:                  defaultHeaderFooterHTML,
+		PaperWidth:                  8.27,
+		PaperHeight:                 11.7,
+		MarginTop:                   1.0,
+		MarginBottom:                1.0,
+		MarginLeft:                  1.0,
+		MarginRight:                 1.0,
+		Landscape:                   false,
+		PageRanges:                  "",
+		RpccBufferSize:              config.DefaultGoogleChromeRpccBufferSize(),
+		CustomHTTPHeaders:           make(map[string]string),
+		Scale:                       1.0,
+		EmulatedMediaType:           "print",
+		PreferCSSPageSize:           false,
+		WaitForExpression:           "",
+		WaitForSelector:             "",
+		FailOnConsoleExceptions:     false,
+		FailOnHTTPStatusCodes:       nil,
+		FailOnResourceLoadingFailed: false,
+		Cookies:                     nil,
+		UserAgent:                   "",
+		ViewportWidth:               0,
+		ViewportHeight:              0,
+		DeviceScaleFactor:           1.0,
+		IsMobile:                    false,
+		Timezone:                    "",
+		Locale:                      "",
+		AllowedURLPatterns:          nil,
+		BlockedURLPatterns:          nil,
+		GlobalAllowedURLPatterns:    config.GoogleChromeAllowedURLPatterns(),
+		GlobalBlockedURLPatterns:    config.GoogleChromeBlockedURLPatterns(),
+		RestrictToDocumentOrigin:    false,
+		DisableJavaScript:           false,
+		GenerateTaggedPDF:           false,
+		GenerateDocumentOutline:     false,
+		HeaderFooterVariants:        HeaderFooterVariants{},
+		TableOfContents:             false,
+		Metadata:                    nil,
+	}
+}
+
+/*
+printToPDFArgs adds to page.PrintToPDFArgs
+the parameters our CDP client does not
+know about yet.
+*/
+type printToPDFArgs struct {
+	*page.PrintToPDFArgs
+	GenerateTaggedPDF       *bool `json:"generateTaggedPDF,omitempty"`
+	GenerateDocumentOutline *bool `json:"generateDocumentOutline,omitempty"`
+}
+
+func newChromePrinter(logger xlog.Logger, url string, opts ChromePrinterOptions) chromePrinter {
+	return chromePrinter{
+		logger:   logger,
+		url:      url,
+		opts:     opts,
+		console:  newConsoleRecorder(),
+		requests: newNetworkRecorder(),
 	}
 }
 
@@ -81,7 +176,14 @@ var devtConnections int
 
 func (p chromePrinter) Print(destination string) error {
 	const op string = "printer.chromePrinter.Print"
-	logOptions(p.logger, p.opts)
+	// do not log the cookie values.
+	loggedOpts := p.opts
+	loggedOpts.Cookies = make([]Cookie, len(p.opts.Cookies))
+	for i, cookie := range p.opts.Cookies {
+		cookie.Value = "***"
+		loggedOpts.Cookies[i] = cookie
+	}
+	logOptions(p.logger, loggedOpts)
 	ctx, cancel := xcontext.WithTimeout(p.logger, p.opts.WaitTimeout+p.opts.WaitDelay)
 	defer cancel()
 	resolver := func() error {
@@ -156,10 +258,42 @@ func (p chromePrinter) Print(destination string) error {
 		if err := p.setCustomHTTPHeaders(ctx, targetClient); err != nil {
 			return err
 		}
+		// add cookies (if any).
+		if err := p.setCookies(ctx, targetClient); err != nil {
+			return err
+		}
+		// emulate the media type.
+		if err := p.emulateMediaType(ctx, targetClient); err != nil {
+			return err
+		}
+		// emulate the device (if asked).
+		if err := p.emulateDevice(ctx, targetClient); err != nil {
+			return err
+		}
+		// override the user agent (if asked).
+		if err := p.setUserAgent(ctx, targetClient); err != nil {
+			return err
+		}
+		// emulate the timezone and the locale (if asked).
+		if err := p.emulateLocale(ctx, targetClient); err != nil {
+			return err
+		}
+		// disable JavaScript (if asked).
+		if err := p.disableJavaScript(ctx, targetClient); err != nil {
+			return err
+		}
+		// abort unwanted requests (if asked).
+		if err := p.interceptRequests(ctx, targetClient); err != nil {
+			return err
+		}
 		// listen for all events.
 		if err := p.listenEvents(ctx, targetClient); err != nil {
 			return err
 		}
+		// wait for the page to be ready (if asked).
+		if err := p.waitForReadiness(ctx, targetClient); err != nil {
+			return err
+		}
 		// apply a wait delay (if any).
 		if p.opts.WaitDelay > 0.0 {
 			// wait for a given amount of time (useful for javascript delay).
@@ -168,51 +302,47 @@ func (p chromePrinter) Print(destination string) error {
 		} else {
 			p.logger.DebugOp(op, "no wait delay to apply, moving on...")
 		}
-		printToPdfArgs := page.NewPrintToPDFArgs().
-			SetPaperWidth(p.opts.PaperWidth).
-			SetPaperHeight(p.opts.PaperHeight).
-			SetMarginTop(p.opts.MarginTop).
-			SetMarginBottom(p.opts.MarginBottom).
-			SetMarginLeft(p.opts.MarginLeft).
-			SetMarginRight(p.opts.MarginRight).
-			SetLandscape(p.opts.Landscape).
-			SetDisplayHeaderFooter(true).
-			SetHeaderTemplate(p.opts.HeaderHTML).
-			SetFooterTemplate(p.opts.FooterHTML).
-			SetPrintBackground(true).
-			SetScale(p.opts.Scale)
-		if p.opts.PageRanges != "" {
-			printToPdfArgs.SetPageRanges(p.opts.PageRanges)
+		// check JavaScript exceptions (if asked).
+		if err := p.checkConsoleExceptions(); err != nil {
+			return err
+		}
+		// check HTTP status codes and resources (if asked).
+		if err := p.checkNetwork(ctx); err != nil {
+			return err
+		}
+		// build the table of contents (if asked).
+		if p.opts.TableOfContents {
+			if err := p.buildTableOfContents(ctx, targetClient, newContextConn); err != nil {
+				return err
+			}
 		}
 		// printToPDF the page to PDF.
-		printToPDF, err := targetClient.Page.PrintToPDF(
-			ctx,
-			printToPdfArgs,
-		)
+		data, err := p.printToPDF(ctx, newContextConn, p.opts.HeaderHTML, p.opts.FooterHTML)
 		if err != nil {
-			// find a way to check it in the handlers?
-			if strings.Contains(err.Error(), "Page range syntax error") {
-				return xerror.Invalid(
-					op,
-					fmt.Sprintf("'%s' is not a valid Google Chrome page ranges", p.opts.PageRanges),
-					err,
-				)
-			}
-			if strings.Contains(err.Error(), "rpcc: message too large") {
-				return xerror.Invalid(
-					op,
-					fmt.Sprintf(
-						"'%d' bytes are not enough: increase the Google Chrome rpcc buffer size (up to 100 MB)",
-						p.opts.RpccBufferSize,
-					),
-					err,
-				)
-			}
 			return err
 		}
-		if err := ioutil.WriteFile(destination, printToPDF.Data, 0600); err != nil {
+		if err := ioutil.WriteFile(destination, data, 0600); err != nil {
 			return err
 		}
+		// apply the header and footer variants (if any).
+		if p.opts.HeaderFooterVariants.any() {
+			data, err = p.applyHeaderFooterVariants(ctx, newContextConn, destination, data)
+			if err != nil {
+				return err
+			}
+		}
+		// older Google Chrome versions ignore the
+		// "generateDocumentOutline" parameter.
+		if p.opts.GenerateDocumentOutline && !hasOutline(data) {
+			p.logger.DebugOp(op, "no outline generated by Google Chrome, building it from the headings...")
+			if err := p.addOutline(ctx, targetClient, destination); err != nil {
+				return err
+			}
+		}
+		// override the metadata (if any).
+		if len(p.opts.Metadata) > 0 {
+			return updatePDFInfo(ctx, p.logger, destination, metadataInfo(p.opts.Metadata))
+		}
 		return nil
 	}
 	if devtConnections < maxDevtConnections {
@@ -255,6 +385,57 @@ func (p chromePrinter) Print(destination string) error {
 	}
 }
 
+func (p chromePrinter) printToPDF(ctx context.Context, conn *rpcc.Conn, headerHTML, footerHTML string) ([]byte, error) {
+	const op string = "printer.chromePrinter.printToPDF"
+	printToPdfArgs := page.NewPrintToPDFArgs().
+		SetPaperWidth(p.opts.PaperWidth).
+		SetPaperHeight(p.opts.PaperHeight).
+		SetMarginTop(p.opts.MarginTop).
+		SetMarginBottom(p.opts.MarginBottom).
+		SetMarginLeft(p.opts.MarginLeft).
+		SetMarginRight(p.opts.MarginRight).
+		SetLandscape(p.opts.Landscape).
+		SetDisplayHeaderFooter(true).
+		SetHeaderTemplate(headerHTML).
+		SetFooterTemplate(footerHTML).
+		SetPrintBackground(true).
+		SetScale(p.opts.Scale).
+		SetPreferCSSPageSize(p.opts.PreferCSSPageSize)
+	if p.opts.PageRanges != "" {
+		printToPdfArgs.SetPageRanges(p.opts.PageRanges)
+	}
+	args := printToPDFArgs{PrintToPDFArgs: printToPdfArgs}
+	if p.opts.GenerateTaggedPDF {
+		args.GenerateTaggedPDF = &p.opts.GenerateTaggedPDF
+	}
+	if p.opts.GenerateDocumentOutline {
+		args.GenerateDocumentOutline = &p.opts.GenerateDocumentOutline
+	}
+	printToPDF := new(page.PrintToPDFReply)
+	if err := rpcc.Invoke(ctx, "Page.printToPDF", args, printToPDF, conn); err != nil {
+		// find a way to check it in the handlers?
+		if strings.Contains(err.Error(), "Page range syntax error") {
+			return nil, xerror.Invalid(
+				op,
+				fmt.Sprintf("'%s' is not a valid Google Chrome page ranges", p.opts.PageRanges),
+				err,
+			)
+		}
+		if strings.Contains(err.Error(), "rpcc: message too large") {
+			return nil, xerror.Invalid(
+				op,
+				fmt.Sprintf(
+					"'%d' bytes are not enough: increase the Google Chrome rpcc buffer size (up to 100 MB)",
+					p.opts.RpccBufferSize,
+				),
+				err,
+			)
+		}
+		return nil, xerror.New(op, err)
+	}
+	return printToPDF.Data, nil
+}
+
 func (p chromePrinter) enableEvents(ctx context.Context, client *cdp.Client) error {
 	const op string = "printer.chromePrinter.enableEvents"
 	// enable all the domain events that we're interested in.
@@ -298,6 +479,233 @@ func (p chromePrinter) setCustomHTTPHeaders(ctx context.Context, client *cdp.Cli
 	return nil
 }
 
+func (p chromePrinter) setCookies(ctx context.Context, client *cdp.Client) error {
+	const op string = "printer.chromePrinter.setCookies"
+	if len(p.opts.Cookies) == 0 {
+		p.logger.DebugOp(op, "skipping cookies as none have been provided...")
+		return nil
+	}
+	cookies := make([]network.CookieParam, len(p.opts.Cookies))
+	for i, cookie := range p.opts.Cookies {
+		param := network.CookieParam{
+			Name:     cookie.Name,
+			Value:    cookie.Value,
+			SameSite: network.CookieSameSite(cookie.SameSite),
+		}
+		if cookie.Domain != "" {
+			domain := cookie.Domain
+			param.Domain = &domain
+		} else {
+			url := p.url
+			param.URL = &url
+		}
+		if cookie.Path != "" {
+			path := cookie.Path
+			param.Path = &path
+		}
+		if cookie.Secure {
+			secure := true
+			param.Secure = &secure
+		}
+		if cookie.HTTPOnly {
+			httpOnly := true
+			param.HTTPOnly = &httpOnly
+		}
+		cookies[i] = param
+		p.logger.DebugOpf(op, "set cookie '%s'", cookie.Name)
+	}
+	/*
+		the target belongs to the isolated browser context,
+		so these cookies do not leak to other conversions.
+		should always be called after client.Network.Enable.
+	*/
+	if err := client.Network.SetCookies(ctx, network.NewSetCookiesArgs(cookies)); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+func (p chromePrinter) emulateMediaType(ctx context.Context, client *cdp.Client) error {
+	const op string = "printer.chromePrinter.emulateMediaType"
+	p.logger.DebugOpf(op, "emulating media type '%s'...", p.opts.EmulatedMediaType)
+	emulatedMediaArgs := emulation.NewSetEmulatedMediaArgs().
+		SetMedia(p.opts.EmulatedMediaType)
+	if err := client.Emulation.SetEmulatedMedia(ctx, emulatedMediaArgs); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+func (p chromePrinter) emulateDevice(ctx context.Context, client *cdp.Client) error {
+	const op string = "printer.chromePrinter.emulateDevice"
+	resolver := func() error {
+		if p.opts.ViewportWidth == 0 &&
+			p.opts.ViewportHeight == 0 &&
+			p.opts.DeviceScaleFactor == 1.0 &&
+			!p.opts.IsMobile {
+			p.logger.DebugOp(op, "skipping device metrics override as none have been provided...")
+			return nil
+		}
+		// a width or height of 0 keeps the default value.
+		p.logger.DebugOpf(
+			op,
+			"emulating a viewport of '%dx%d' with a device scale factor of '%.2f' (mobile: '%t')...",
+			p.opts.ViewportWidth,
+			p.opts.ViewportHeight,
+			p.opts.DeviceScaleFactor,
+			p.opts.IsMobile,
+		)
+		deviceMetricsOverrideArgs := emulation.NewSetDeviceMetricsOverrideArgs(
+			int(p.opts.ViewportWidth),
+			int(p.opts.ViewportHeight),
+			p.opts.DeviceScaleFactor,
+			p.opts.IsMobile,
+		)
+		return client.Emulation.SetDeviceMetricsOverride(ctx, deviceMetricsOverrideArgs)
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+func (p chromePrinter) setUserAgent(ctx context.Context, client *cdp.Client) error {
+	const op string = "printer.chromePrinter.setUserAgent"
+	resolver := func() error {
+		if p.opts.UserAgent == "" && p.opts.Locale == "" {
+			p.logger.DebugOp(op, "skipping user agent override as none has been provided...")
+			return nil
+		}
+		userAgent := p.opts.UserAgent
+		if userAgent == "" {
+			// the Accept-Language header comes with
+			// the user agent: we keep the default one.
+			version, err := client.Browser.GetVersion(ctx)
+			if err != nil {
+				return err
+			}
+			userAgent = version.UserAgent
+		}
+		p.logger.DebugOpf(op, "set user agent '%s'", userAgent)
+		/*
+			Network.setUserAgentOverride is deprecated
+			and not available in our CDP client:
+			Emulation.setUserAgentOverride is its replacement.
+		*/
+		userAgentOverrideArgs := emulation.NewSetUserAgentOverrideArgs(userAgent)
+		if p.opts.Locale != "" {
+			p.logger.DebugOpf(op, "set Accept-Language '%s'", p.opts.Locale)
+			userAgentOverrideArgs.SetAcceptLanguage(p.opts.Locale)
+		}
+		return client.Emulation.SetUserAgentOverride(ctx, userAgentOverrideArgs)
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+func (p chromePrinter) emulateLocale(ctx context.Context, client *cdp.Client) error {
+	const op string = "printer.chromePrinter.emulateLocale"
+	if p.opts.Timezone != "" {
+		p.logger.DebugOpf(op, "emulating timezone '%s'...", p.opts.Timezone)
+		err := client.Emulation.SetTimezoneOverride(ctx, emulation.NewSetTimezoneOverrideArgs(p.opts.Timezone))
+		if err != nil {
+			// find a way to check it in the handlers?
+			if strings.Contains(err.Error(), "Invalid timezone") {
+				return xerror.Invalid(
+					op,
+					fmt.Sprintf("'%s' is not a valid timezone", p.opts.Timezone),
+					err,
+				)
+			}
+			return xerror.New(op, err)
+		}
+	}
+	if p.opts.Locale != "" {
+		p.logger.DebugOpf(op, "emulating locale '%s'...", p.opts.Locale)
+		err := client.Emulation.SetLocaleOverride(ctx, emulation.NewSetLocaleOverrideArgs().SetLocale(p.opts.Locale))
+		if err != nil {
+			if strings.Contains(err.Error(), "Invalid locale") {
+				return xerror.Invalid(
+					op,
+					fmt.Sprintf("'%s' is not a valid locale", p.opts.Locale),
+					err,
+				)
+			}
+			return xerror.New(op, err)
+		}
+	}
+	return nil
+}
+
+func (p chromePrinter) disableJavaScript(ctx context.Context, client *cdp.Client) error {
+	const op string = "printer.chromePrinter.disableJavaScript"
+	if !p.opts.DisableJavaScript {
+		p.logger.DebugOp(op, "JavaScript enabled, moving on...")
+		return nil
+	}
+	p.logger.DebugOp(op, "disabling JavaScript...")
+	if err := client.Emulation.SetScriptExecutionDisabled(ctx, emulation.NewSetScriptExecutionDisabledArgs(true)); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+func (p chromePrinter) interceptRequests(ctx context.Context, client *cdp.Client) error {
+	const op string = "printer.chromePrinter.interceptRequests"
+	resolver := func() error {
+		filter, err := newURLFilter(p.url, p.opts)
+		if err != nil {
+			return err
+		}
+		if !filter.enabled() {
+			p.logger.DebugOp(op, "skipping requests interception as no URL patterns nor restriction have been provided...")
+			return nil
+		}
+		/*
+			this client is closed with the connection
+			as requests may occur after the page
+			has been loaded.
+		*/
+		requestPaused, err := client.Fetch.RequestPaused(ctx)
+		if err != nil {
+			return err
+		}
+		go p.filterRequests(ctx, client, filter, requestPaused)
+		// pause all requests.
+		return client.Fetch.Enable(ctx, fetch.NewEnableArgs())
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+func (p chromePrinter) filterRequests(
+	ctx context.Context,
+	client *cdp.Client,
+	filter urlFilter,
+	requestPaused fetch.RequestPausedClient,
+) {
+	const op string = "printer.chromePrinter.filterRequests"
+	for {
+		ev, err := requestPaused.Recv()
+		if err != nil {
+			return
+		}
+		if filter.allow(ev.Request.URL) {
+			err = client.Fetch.ContinueRequest(ctx, fetch.NewContinueRequestArgs(ev.RequestID))
+		} else {
+			p.logger.DebugOpf(op, "request to '%s' aborted", ev.Request.URL)
+			err = client.Fetch.FailRequest(ctx, fetch.NewFailRequestArgs(ev.RequestID, network.ErrorReasonBlockedByClient))
+		}
+		if err != nil {
+			p.logger.DebugOpf(op, "failed to resume request to '%s': %s", ev.Request.URL, err.Error())
+		}
+	}
+}
+
 func (p chromePrinter) listenEvents(ctx context.Context, client *cdp.Client) error {
 	const op string = "printer.chromePrinter.listenEvents"
 	resolver := func() error {
@@ -330,11 +738,47 @@ func (p chromePrinter) listenEvents(ctx context.Context, client *cdp.Client) err
 			return err
 		}
 		defer loadingFinished.Close()
-		if _, err := client.Page.Navigate(ctx, page.NewNavigateArgs(p.url)); err != nil {
+		/*
+			the following clients are closed with the
+			connection as exceptions and console messages
+			may occur after the page has been loaded.
+		*/
+		exceptionThrown, err := client.Runtime.ExceptionThrown(ctx)
+		if err != nil {
+			return err
+		}
+		go p.recordExceptions(exceptionThrown)
+		consoleAPICalled, err := client.Runtime.ConsoleAPICalled(ctx)
+		if err != nil {
+			return err
+		}
+		go p.recordConsoleMessages(consoleAPICalled)
+		requestWillBeSent, err := client.Network.RequestWillBeSent(ctx)
+		if err != nil {
+			return err
+		}
+		responseReceived, err := client.Network.ResponseReceived(ctx)
+		if err != nil {
+			return err
+		}
+		loadingFailed, err := client.Network.LoadingFailed(ctx)
+		if err != nil {
 			return err
 		}
+		// keep the order of the network events.
+		if err := rpcc.Sync(requestWillBeSent, responseReceived, loadingFailed); err != nil {
+			return err
+		}
+		go p.recordNetwork(requestWillBeSent, responseReceived, loadingFailed)
+		navigate, err := client.Page.Navigate(ctx, page.NewNavigateArgs(p.url))
+		if err != nil {
+			return err
+		}
+		if navigate.LoaderID != nil {
+			p.requests.setMainLoaderID(*navigate.LoaderID)
+		}
 		// wait for all events.
-		return runBatch(
+		events := []func() error{
 			func() error {
 				_, err := domContentEventFired.Recv()
 				if err != nil {
@@ -351,6 +795,19 @@ func (p chromePrinter) listenEvents(ctx context.Context, client *cdp.Client) err
 				p.logger.DebugOp(op, "event 'loadEventFired' received")
 				return nil
 			},
+		}
+		if p.opts.DisableJavaScript {
+			/*
+				without JavaScript, no request may occur
+				after the load event: waiting for the
+				network to be idle would only slow
+				down the conversion.
+			*/
+			p.logger.DebugOp(op, "JavaScript disabled, not waiting for the network to be idle...")
+			return runBatch(events...)
+		}
+		events = append(
+			events,
 			func() error {
 				const networkIdleEventName string = "networkIdle"
 				for {
@@ -374,6 +831,148 @@ func (p chromePrinter) listenEvents(ctx context.Context, client *cdp.Client) err
 				return nil
 			},
 		)
+		return runBatch(events...)
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+func (p chromePrinter) recordExceptions(exceptionThrown runtime.ExceptionThrownClient) {
+	const op string = "printer.chromePrinter.recordExceptions"
+	for {
+		ev, err := exceptionThrown.Recv()
+		if err != nil {
+			return
+		}
+		exception := p.console.recordException(ev)
+		p.logger.DebugOpf(op, "JavaScript exception: %s", exception)
+	}
+}
+
+func (p chromePrinter) recordConsoleMessages(consoleAPICalled runtime.ConsoleAPICalledClient) {
+	const op string = "printer.chromePrinter.recordConsoleMessages"
+	for {
+		ev, err := consoleAPICalled.Recv()
+		if err != nil {
+			return
+		}
+		message := p.console.recordMessage(ev)
+		p.logger.DebugOpf(op, "JavaScript console: %s", message)
+	}
+}
+
+func (p chromePrinter) checkConsoleExceptions() error {
+	const op string = "printer.chromePrinter.checkConsoleExceptions"
+	if !p.opts.FailOnConsoleExceptions {
+		return nil
+	}
+	exceptions := p.console.allExceptions()
+	if len(exceptions) == 0 {
+		return nil
+	}
+	return xerror.Invalid(
+		op,
+		fmt.Sprintf("JavaScript exceptions have been thrown: %s", strings.Join(exceptions, "; ")),
+		nil,
+	)
+}
+
+func (p chromePrinter) recordNetwork(
+	requestWillBeSent network.RequestWillBeSentClient,
+	responseReceived network.ResponseReceivedClient,
+	loadingFailed network.LoadingFailedClient,
+) {
+	const op string = "printer.chromePrinter.recordNetwork"
+	for {
+		select {
+		case <-requestWillBeSent.Ready():
+			ev, err := requestWillBeSent.Recv()
+			if err != nil {
+				return
+			}
+			p.requests.recordRequest(ev)
+		case <-responseReceived.Ready():
+			ev, err := responseReceived.Recv()
+			if err != nil {
+				return
+			}
+			p.logger.DebugOpf(op, "response '%d' received from '%s'", ev.Response.Status, ev.Response.URL)
+			p.requests.recordResponse(ev)
+		case <-loadingFailed.Ready():
+			ev, err := loadingFailed.Recv()
+			if err != nil {
+				return
+			}
+			p.logger.DebugOpf(op, "loading of request '%s' failed: %s", ev.RequestID, ev.ErrorText)
+			p.requests.recordLoadingFailed(ev)
+		}
+	}
+}
+
+func (p chromePrinter) checkNetwork(ctx context.Context) error {
+	const op string = "printer.chromePrinter.checkNetwork"
+	if len(p.opts.FailOnHTTPStatusCodes) > 0 {
+		if err := p.requests.waitMainDocument(ctx); err != nil {
+			return xerror.New(op, err)
+		}
+	}
+	statusCode := p.requests.mainDocumentStatusCode()
+	if statusCode != 0 && matchHTTPStatusCode(statusCode, p.opts.FailOnHTTPStatusCodes) {
+		return xerror.Invalid(
+			op,
+			fmt.Sprintf("'%s' returned the HTTP status code '%d'", p.url, statusCode),
+			nil,
+		)
+	}
+	if !p.opts.FailOnResourceLoadingFailed {
+		return nil
+	}
+	failedResources := p.requests.allFailedResources()
+	if len(failedResources) == 0 {
+		return nil
+	}
+	return xerror.Invalid(
+		op,
+		fmt.Sprintf("some resources failed to load: %s", strings.Join(failedResources, ", ")),
+		nil,
+	)
+}
+
+// ConsoleMessages returns the JavaScript console
+// messages and exceptions captured during
+// the conversion.
+func (p chromePrinter) ConsoleMessages() []string {
+	if p.console == nil {
+		return nil
+	}
+	return p.console.allMessages()
+}
+
+func (p chromePrinter) waitForReadiness(ctx context.Context, client *cdp.Client) error {
+	const op string = "printer.chromePrinter.waitForReadiness"
+	resolver := func() error {
+		if p.opts.WaitForExpression == "" && p.opts.WaitForSelector == "" {
+			p.logger.DebugOp(op, "no readiness expression nor selector to wait for, moving on...")
+			return nil
+		}
+		if p.opts.WaitForExpression != "" {
+			if err := p.waitForExpression(ctx, client, p.opts.WaitForExpression); err != nil {
+				return err
+			}
+		}
+		if p.opts.WaitForSelector != "" {
+			selector, err := json.Marshal(p.opts.WaitForSelector)
+			if err != nil {
+				return err
+			}
+			expression := fmt.Sprintf("document.querySelector(%s) !== null", selector)
+			if err := p.waitForExpression(ctx, client, expression); err != nil {
+				return err
+			}
+		}
+		return nil
 	}
 	if err := resolver(); err != nil {
 		return xerror.New(op, err)
@@ -381,6 +980,40 @@ func (p chromePrinter) listenEvents(ctx context.Context, client *cdp.Client) err
 	return nil
 }
 
+func (p chromePrinter) waitForExpression(ctx context.Context, client *cdp.Client, expression string) error {
+	const (
+		op              string  = "printer.chromePrinter.waitForExpression"
+		pollingInterval float64 = 0.1
+	)
+	p.logger.DebugOpf(op, "waiting for '%s' to be true...", expression)
+	evaluateArgs := runtime.NewEvaluateArgs(expression).SetReturnByValue(true)
+	for {
+		evaluate, err := client.Runtime.Evaluate(ctx, evaluateArgs)
+		if err != nil && ctx.Err() == nil {
+			return xerror.New(op, err)
+		}
+		if err == nil {
+			if evaluate.ExceptionDetails != nil {
+				// the page might not be ready yet, so
+				// we keep on polling.
+				p.logger.DebugOpf(op, "'%s' threw an exception: %s", expression, evaluate.ExceptionDetails.Text)
+			} else if string(evaluate.Result.Value) == "true" {
+				p.logger.DebugOpf(op, "'%s' is true", expression)
+				return nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return xerror.Timeout(
+				op,
+				fmt.Sprintf("'%s' was not true before the wait timeout", expression),
+				ctx.Err(),
+			)
+		case <-time.After(xtime.Duration(pollingInterval)):
+		}
+	}
+}
+
 func runBatch(fn ...func() error) error {
 	// run all functions simultaneously and wait until
 	// execution has completed or an error is encountered.
@@ -394,4 +1027,5 @@ func runBatch(fn ...func() error) error {
 // Compile-time checks to ensure type implements desired interfaces.
 var (
 	_ = Printer(new(chromePrinter))
+	_ = ConsoleReporter(new(chromePrinter))
 )
@@ -0,0 +1,99 @@
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/mafredri/cdp/protocol/runtime"
+)
+
+/*
+ConsoleReporter is a Printer which captures
+the JavaScript console output and exceptions
+of the page it converts.
+*/
+type ConsoleReporter interface {
+	ConsoleMessages() []string
+}
+
+const (
+	maxConsoleMessages      int = 50
+	maxConsoleMessageLength int = 200
+)
+
+// consoleRecorder stores the JavaScript console
+// messages and exceptions of a page.
+type consoleRecorder struct {
+	mu         sync.Mutex
+	messages   []string
+	exceptions []string
+}
+
+func newConsoleRecorder() *consoleRecorder {
+	return &consoleRecorder{}
+}
+
+func (c *consoleRecorder) recordMessage(ev *runtime.ConsoleAPICalledReply) string {
+	args := make([]string, len(ev.Args))
+	for i, arg := range ev.Args {
+		args[i] = remoteObjectToString(arg)
+	}
+	message := fmt.Sprintf("%s: %s", ev.Type, strings.Join(args, " "))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.messages = appendConsoleMessage(c.messages, message)
+	return message
+}
+
+func (c *consoleRecorder) recordException(ev *runtime.ExceptionThrownReply) string {
+	exception := ev.ExceptionDetails.Text
+	if ev.ExceptionDetails.Exception != nil {
+		exception = fmt.Sprintf("%s %s", exception, remoteObjectToString(*ev.ExceptionDetails.Exception))
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.exceptions = appendConsoleMessage(c.exceptions, exception)
+	c.messages = appendConsoleMessage(c.messages, fmt.Sprintf("exception: %s", exception))
+	return exception
+}
+
+func (c *consoleRecorder) allMessages() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.messages...)
+}
+
+func (c *consoleRecorder) allExceptions() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.exceptions...)
+}
+
+func appendConsoleMessage(messages []string, message string) []string {
+	if len(messages) >= maxConsoleMessages {
+		return messages
+	}
+	if len(message) > maxConsoleMessageLength {
+		message = fmt.Sprintf("%s...", message[:maxConsoleMessageLength])
+	}
+	return append(messages, message)
+}
+
+func remoteObjectToString(obj runtime.RemoteObject) string {
+	if obj.Description != nil {
+		return *obj.Description
+	}
+	if obj.UnserializableValue != nil {
+		return string(*obj.UnserializableValue)
+	}
+	if len(obj.Value) == 0 {
+		return obj.Type
+	}
+	var str string
+	if err := json.Unmarshal(obj.Value, &str); err == nil {
+		return str
+	}
+	return string(obj.Value)
+}
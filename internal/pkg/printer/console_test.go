@@ -0,0 +1,50 @@
+package printer
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mafredri/cdp/protocol/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsoleRecorder(t *testing.T) {
+	c := newConsoleRecorder()
+	// console messages.
+	message := c.recordMessage(&runtime.ConsoleAPICalledReply{
+		Type: "log",
+		Args: []runtime.RemoteObject{
+			{Type: "string", Value: json.RawMessage(`"foo"`)},
+			{Type: "number", Value: json.RawMessage(`1`)},
+		},
+	})
+	assert.Equal(t, "log: foo 1", message)
+	assert.Equal(t, []string{"log: foo 1"}, c.allMessages())
+	assert.Empty(t, c.allExceptions())
+	// exceptions.
+	description := "ReferenceError: foo is not defined"
+	exception := c.recordException(&runtime.ExceptionThrownReply{
+		ExceptionDetails: runtime.ExceptionDetails{
+			Text:      "Uncaught",
+			Exception: &runtime.RemoteObject{Type: "object", Description: &description},
+		},
+	})
+	assert.Equal(t, "Uncaught ReferenceError: foo is not defined", exception)
+	assert.Equal(t, []string{exception}, c.allExceptions())
+	assert.Len(t, c.allMessages(), 2)
+	// messages should be truncated.
+	message = c.recordMessage(&runtime.ConsoleAPICalledReply{
+		Type: "error",
+		Args: []runtime.RemoteObject{
+			{Type: "string", Value: json.RawMessage(`"` + strings.Repeat("a", 300) + `"`)},
+		},
+	})
+	assert.Len(t, c.allMessages()[2], maxConsoleMessageLength+len("..."))
+	assert.Len(t, message, len("error: ")+300)
+	// messages should be capped.
+	for i := 0; i < maxConsoleMessages; i++ {
+		c.recordMessage(&runtime.ConsoleAPICalledReply{Type: "log"})
+	}
+	assert.Len(t, c.allMessages(), maxConsoleMessages)
+}
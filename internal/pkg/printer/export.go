@@ -0,0 +1,95 @@
+package printer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+PDFExportOptions helps customizing the
+PDF export filter of LibreOffice.
+
+A nil option is not given to LibreOffice,
+which uses its own default instead.
+*/
+type PDFExportOptions struct {
+	Quality                  *int64
+	ReduceImageResolution    *bool
+	MaxImageResolution       *int64
+	LosslessImageCompression *bool
+	ExportBookmarks          *bool
+	ExportNotes              *bool
+	ExportNotesPages         *bool
+	ExportFormFields         *bool
+	SkipEmptyPages           *bool
+}
+
+// DefaultPDFExportOptions returns the default
+// PDF export options, i.e. none, so that
+// LibreOffice uses its own defaults.
+func DefaultPDFExportOptions() PDFExportOptions {
+	return PDFExportOptions{}
+}
+
+// PDFExportMaxImageResolutions returns the
+// available maximum resolutions (DPI) of the
+// images, if their resolution is reduced.
+func PDFExportMaxImageResolutions() []string {
+	return []string{
+		"75",
+		"150",
+		"300",
+		"600",
+		"1200",
+	}
+}
+
+/*
+unoconvArgs returns the "--export" arguments
+of unoconv for the PDF export filter. Only the
+given options are returned.
+
+Note: unoconv converts the "true" and "false"
+values to booleans, and the numeric values to
+integers.
+*/
+func (opts PDFExportOptions) unoconvArgs() []string {
+	var args []string
+	for _, data := range opts.filterData() {
+		args = append(args, "--export", data)
+	}
+	return args
+}
+
+// String returns the given options, as
+// pointers are not readable in the logs.
+func (opts PDFExportOptions) String() string {
+	return fmt.Sprintf("{%s}", strings.Join(opts.filterData(), " "))
+}
+
+// filterData returns the given options as
+// "name=value" pairs of the PDF export filter.
+func (opts PDFExportOptions) filterData() []string {
+	var data []string
+	appendInt64 := func(name string, value *int64) {
+		if value != nil {
+			data = append(data, fmt.Sprintf("%s=%s", name, strconv.FormatInt(*value, 10)))
+		}
+	}
+	appendBool := func(name string, value *bool) {
+		if value != nil {
+			data = append(data, fmt.Sprintf("%s=%s", name, strconv.FormatBool(*value)))
+		}
+	}
+	appendInt64("Quality", opts.Quality)
+	appendBool("ReduceImageResolution", opts.ReduceImageResolution)
+	appendInt64("MaxImageResolution", opts.MaxImageResolution)
+	appendBool("UseLosslessCompression", opts.LosslessImageCompression)
+	appendBool("ExportBookmarks", opts.ExportBookmarks)
+	appendBool("ExportNotes", opts.ExportNotes)
+	appendBool("ExportNotesPages", opts.ExportNotesPages)
+	appendBool("ExportFormFields", opts.ExportFormFields)
+	appendBool("IsSkipEmptyPages", opts.SkipEmptyPages)
+	return data
+}
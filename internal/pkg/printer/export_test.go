@@ -0,0 +1,38 @@
+package printer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPDFExportOptionsUnoconvArgs(t *testing.T) {
+	// default options.
+	assert.Empty(t, DefaultPDFExportOptions().unoconvArgs())
+	// custom options.
+	quality := int64(50)
+	reduceImageResolution := true
+	maxImageResolution := int64(150)
+	exportBookmarks := false
+	skipEmptyPages := true
+	opts := DefaultPDFExportOptions()
+	opts.Quality = &quality
+	opts.ReduceImageResolution = &reduceImageResolution
+	opts.MaxImageResolution = &maxImageResolution
+	opts.ExportBookmarks = &exportBookmarks
+	opts.SkipEmptyPages = &skipEmptyPages
+	expected := []string{
+		"--export", "Quality=50",
+		"--export", "ReduceImageResolution=true",
+		"--export", "MaxImageResolution=150",
+		"--export", "ExportBookmarks=false",
+		"--export", "IsSkipEmptyPages=true",
+	}
+	assert.Equal(t, expected, opts.unoconvArgs())
+	assert.Equal(
+		t,
+		"{Quality=50 ReduceImageResolution=true MaxImageResolution=150 ExportBookmarks=false IsSkipEmptyPages=true}",
+		fmt.Sprintf("%+v", opts),
+	)
+}
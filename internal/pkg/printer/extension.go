@@ -0,0 +1,246 @@
+package printer
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+)
+
+// officeDocumentKind is the kind of
+// document of a file extension.
+type officeDocumentKind int
+
+const (
+	textDocument officeDocumentKind = iota
+	spreadsheet
+	presentation
+	drawing
+)
+
+// officeExtension is a file extension
+// of the Office conversions.
+type officeExtension struct {
+	ext         string
+	description string
+	kind        officeDocumentKind
+}
+
+// officeOutputFormat is a format LibreOffice
+// converts the given kinds of documents to.
+type officeOutputFormat struct {
+	format      string
+	contentType string
+	kinds       []officeDocumentKind
+}
+
+// csvEncoding is a character set
+// of the CSV files, with its
+// LibreOffice identifier.
+type csvEncoding struct {
+	name string
+	id   int
+}
+
+// nolint: gochecknoglobals
+var (
+	// officeExtensions are the file extensions
+	// LibreOffice converts to PDF. The OpenAPI
+	// and the Office documentations list the
+	// very same extensions.
+	officeExtensions = []officeExtension{
+		// text documents.
+		{".txt", "Plain text", textDocument},
+		{".rtf", "Rich Text Format", textDocument},
+		{".doc", "Microsoft Word 97-2003", textDocument},
+		{".docx", "Microsoft Word", textDocument},
+		{".odt", "OpenDocument Text", textDocument},
+		{".fodt", "Flat OpenDocument Text", textDocument},
+		{".wpd", "WordPerfect", textDocument},
+		{".wps", "Microsoft Works", textDocument},
+		{".xml", "Microsoft Word 2003 XML", textDocument},
+		// spreadsheets.
+		{".csv", "Comma-separated values", spreadsheet},
+		{".xls", "Microsoft Excel 97-2003", spreadsheet},
+		{".xlsx", "Microsoft Excel", spreadsheet},
+		{".ods", "OpenDocument Spreadsheet", spreadsheet},
+		{".fods", "Flat OpenDocument Spreadsheet", spreadsheet},
+		// presentations.
+		{".ppt", "Microsoft PowerPoint 97-2003", presentation},
+		{".pptx", "Microsoft PowerPoint", presentation},
+		{".odp", "OpenDocument Presentation", presentation},
+		{".fodp", "Flat OpenDocument Presentation", presentation},
+		// drawings.
+		{".odg", "OpenDocument Drawing", drawing},
+		{".fodg", "Flat OpenDocument Drawing", drawing},
+		{".vsd", "Microsoft Visio 2003-2010", drawing},
+		{".vsdx", "Microsoft Visio", drawing},
+		{".pub", "Microsoft Publisher", drawing},
+	}
+	// officeOutputFormats are the formats LibreOffice
+	// converts the Office documents to.
+	officeOutputFormats = []officeOutputFormat{
+		{
+			"pdf",
+			"application/pdf",
+			[]officeDocumentKind{textDocument, spreadsheet, presentation, drawing},
+		},
+		{
+			"docx",
+			"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+			[]officeDocumentKind{textDocument},
+		},
+		{
+			"odt",
+			"application/vnd.oasis.opendocument.text",
+			[]officeDocumentKind{textDocument},
+		},
+		{
+			"html",
+			"text/html",
+			[]officeDocumentKind{textDocument},
+		},
+		{
+			"xlsx",
+			"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+			[]officeDocumentKind{spreadsheet},
+		},
+		{
+			"ods",
+			"application/vnd.oasis.opendocument.spreadsheet",
+			[]officeDocumentKind{spreadsheet},
+		},
+		{
+			"csv",
+			"text/csv",
+			[]officeDocumentKind{spreadsheet},
+		},
+		{
+			"pptx",
+			"application/vnd.openxmlformats-officedocument.presentationml.presentation",
+			[]officeDocumentKind{presentation},
+		},
+		{
+			"odp",
+			"application/vnd.oasis.opendocument.presentation",
+			[]officeDocumentKind{presentation},
+		},
+		// one image per page.
+		{
+			"png",
+			"image/png",
+			[]officeDocumentKind{presentation, drawing},
+		},
+	}
+	csvEncodings = []csvEncoding{
+		{"UTF-8", 76},
+		{"UTF-16", 65535},
+		{"US-ASCII", 11},
+		{"ISO-8859-1", 12},
+		{"ISO-8859-2", 13},
+		{"ISO-8859-15", 22},
+		{"windows-1250", 33},
+		{"windows-1251", 34},
+		{"windows-1252", 1},
+	}
+)
+
+// OfficeExtensions returns the file extensions
+// accepted by the Office conversions.
+func OfficeExtensions() []string {
+	exts := make([]string, len(officeExtensions))
+	for i, ext := range officeExtensions {
+		exts[i] = ext.ext
+	}
+	return exts
+}
+
+// OfficeOutputFormats returns the formats
+// of the Office conversions.
+func OfficeOutputFormats() []string {
+	formats := make([]string, len(officeOutputFormats))
+	for i, format := range officeOutputFormats {
+		formats[i] = format.format
+	}
+	return formats
+}
+
+// lookupOfficeOutputFormat returns
+// given output format, if any.
+func lookupOfficeOutputFormat(format string) (officeOutputFormat, bool) {
+	for _, f := range officeOutputFormats {
+		if f.format == format {
+			return f, true
+		}
+	}
+	return officeOutputFormat{}, false
+}
+
+/*
+ValidateOfficeConversion returns an error if
+one of the given files cannot be converted to
+given output format (e.g. a spreadsheet to
+"docx").
+*/
+func ValidateOfficeConversion(fpaths []string, format string) error {
+	const op string = "printer.ValidateOfficeConversion"
+	outputFormat, ok := lookupOfficeOutputFormat(format)
+	if !ok {
+		return xerror.Invalid(
+			op,
+			fmt.Sprintf("'%s' is not an output format", format),
+			nil,
+		)
+	}
+	for _, fpath := range fpaths {
+		ext := strings.ToLower(filepath.Ext(fpath))
+		supported := false
+		for _, e := range officeExtensions {
+			if e.ext != ext {
+				continue
+			}
+			for _, kind := range outputFormat.kinds {
+				supported = supported || e.kind == kind
+			}
+		}
+		if !supported {
+			return xerror.Invalid(
+				op,
+				fmt.Sprintf("'%s' cannot be converted to '%s'", filepath.Base(fpath), format),
+				nil,
+			)
+		}
+	}
+	return nil
+}
+
+// CSVEncodings returns the available
+// character sets of the CSV files.
+func CSVEncodings() []string {
+	encodings := make([]string, len(csvEncodings))
+	for i, encoding := range csvEncodings {
+		encodings[i] = encoding.name
+	}
+	return encodings
+}
+
+/*
+csvFilterOptions returns the LibreOffice
+filter options of the CSV files: the field
+delimiter, the text delimiter (a double quote)
+and the character set.
+*/
+func csvFilterOptions(delimiter, encoding string) string {
+	charset := csvEncodings[0].id
+	for _, e := range csvEncodings {
+		if e.name == encoding {
+			charset = e.id
+		}
+	}
+	separator := int(',')
+	if len(delimiter) == 1 {
+		separator = int(delimiter[0])
+	}
+	return fmt.Sprintf("FilterOptions=%d,%d,%d", separator, '"', charset)
+}
@@ -0,0 +1,87 @@
+package printer
+
+import (
+	"io/ioutil"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thecodingmachine/gotenberg/test"
+)
+
+func TestOfficeExtensions(t *testing.T) {
+	exts := OfficeExtensions()
+	assert.Equal(t, len(officeExtensions), len(exts))
+	assert.Contains(t, exts, ".docx")
+	assert.Contains(t, exts, ".csv")
+	assert.Contains(t, exts, ".vsdx")
+	// the documentations list the
+	// very same extensions.
+	docRegexp := regexp.MustCompile("(?m)^\\s*[-*] `(\\.[a-z]+)` - (.+)$")
+	for _, fpath := range []string{
+		"../../../docs/openapi.yaml",
+		"../../../build/docs/content/07-office.md",
+	} {
+		b, err := ioutil.ReadFile(fpath)
+		assert.Nil(t, err)
+		var expected, documented []string
+		for _, ext := range officeExtensions {
+			expected = append(expected, ext.ext+" - "+ext.description)
+		}
+		for _, match := range docRegexp.FindAllStringSubmatch(string(b), -1) {
+			documented = append(documented, match[1]+" - "+match[2])
+		}
+		assert.Equal(t, expected, documented, fpath)
+	}
+}
+
+func TestOfficeOutputFormats(t *testing.T) {
+	formats := OfficeOutputFormats()
+	assert.Equal(t, len(officeOutputFormats), len(formats))
+	assert.Equal(t, "pdf", formats[0])
+	assert.Contains(t, formats, "docx")
+	assert.Contains(t, formats, "png")
+	// the OpenAPI documentation lists
+	// the very same formats.
+	b, err := ioutil.ReadFile("../../../docs/openapi.yaml")
+	assert.Nil(t, err)
+	for _, format := range formats {
+		assert.Contains(t, string(b), "- "+format+"\n", format)
+	}
+}
+
+func TestValidateOfficeConversion(t *testing.T) {
+	// every document converts to PDF.
+	err := ValidateOfficeConversion([]string{"/foo/a.docx", "/foo/b.xlsx", "/foo/c.pptx", "/foo/d.vsdx"}, "pdf")
+	assert.Nil(t, err)
+	// text documents to "docx".
+	err = ValidateOfficeConversion([]string{"/foo/a.odt", "/foo/b.TXT"}, "docx")
+	assert.Nil(t, err)
+	// spreadsheets to "csv".
+	err = ValidateOfficeConversion([]string{"/foo/a.xlsx", "/foo/b.csv"}, "csv")
+	assert.Nil(t, err)
+	// presentations and drawings to "png".
+	err = ValidateOfficeConversion([]string{"/foo/a.pptx", "/foo/b.odg"}, "png")
+	assert.Nil(t, err)
+	// should not be OK as a text
+	// document is not a spreadsheet.
+	err = ValidateOfficeConversion([]string{"/foo/a.xlsx", "/foo/b.docx"}, "csv")
+	test.AssertError(t, err)
+	// should not be OK as text
+	// documents are not images.
+	err = ValidateOfficeConversion([]string{"/foo/a.docx"}, "png")
+	test.AssertError(t, err)
+	// should not be OK as the
+	// output format does not exist.
+	err = ValidateOfficeConversion([]string{"/foo/a.docx"}, "foo")
+	test.AssertError(t, err)
+}
+
+func TestCSVFilterOptions(t *testing.T) {
+	// default options.
+	assert.Equal(t, "FilterOptions=44,34,76", csvFilterOptions(",", "UTF-8"))
+	// options with a semicolon and Latin-1.
+	assert.Equal(t, "FilterOptions=59,34,12", csvFilterOptions(";", "ISO-8859-1"))
+	// options with a tab.
+	assert.Equal(t, "FilterOptions=9,34,76", csvFilterOptions("\t", "UTF-8"))
+}
@@ -0,0 +1,193 @@
+package printer
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+/*
+urlFilter decides which requests of a page
+are allowed.
+
+A request is aborted if its URL matches a blocked
+pattern, or if it does not match at least one
+pattern of each non-empty list of allowed patterns.
+*/
+type urlFilter struct {
+	documentURL      string
+	documentOrigin   string
+	restrictToOrigin bool
+	allowed          [][]*regexp.Regexp
+	blocked          []*regexp.Regexp
+}
+
+func newURLFilter(documentURL string, opts ChromePrinterOptions) (urlFilter, error) {
+	f := urlFilter{
+		documentURL:      normalizeURL(documentURL),
+		restrictToOrigin: opts.RestrictToDocumentOrigin,
+	}
+	if opts.RestrictToDocumentOrigin {
+		origin, err := urlOrigin(documentURL)
+		if err != nil {
+			return f, err
+		}
+		f.documentOrigin = origin
+	}
+	for _, patterns := range [][]string{opts.GlobalAllowedURLPatterns, opts.AllowedURLPatterns} {
+		if len(patterns) == 0 {
+			continue
+		}
+		allowed, err := compileURLPatterns(patterns)
+		if err != nil {
+			return f, err
+		}
+		f.allowed = append(f.allowed, allowed)
+	}
+	for _, patterns := range [][]string{opts.GlobalBlockedURLPatterns, opts.BlockedURLPatterns} {
+		blocked, err := compileURLPatterns(patterns)
+		if err != nil {
+			return f, err
+		}
+		f.blocked = append(f.blocked, blocked...)
+	}
+	return f, nil
+}
+
+// enabled returns true if some requests
+// might be aborted.
+func (f urlFilter) enabled() bool {
+	return f.restrictToOrigin || len(f.allowed) > 0 || len(f.blocked) > 0
+}
+
+func (f urlFilter) allow(rawURL string) bool {
+	// the document itself is always allowed.
+	if normalizeURL(rawURL) == f.documentURL || strings.HasPrefix(rawURL, "data:") {
+		return true
+	}
+	// so are the bundled renderers.
+	if isRendererURL(rawURL) {
+		return true
+	}
+	if f.restrictToOrigin && !f.inDocumentOrigin(rawURL) {
+		return false
+	}
+	for _, pattern := range f.blocked {
+		if pattern.MatchString(rawURL) {
+			return false
+		}
+	}
+	for _, patterns := range f.allowed {
+		if !matchAny(patterns, rawURL) {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+inDocumentOrigin returns true if given URL
+has the same origin as the document.
+
+For file:// documents, the URL has to target
+the directory of the document (or one of its
+sub-directories).
+*/
+func (f urlFilter) inDocumentOrigin(rawURL string) bool {
+	origin, err := urlOrigin(rawURL)
+	if err != nil {
+		return false
+	}
+	if strings.HasPrefix(f.documentOrigin, "file://") {
+		return strings.HasPrefix(origin, f.documentOrigin)
+	}
+	return origin == f.documentOrigin
+}
+
+// isRendererURL returns true if given URL
+// targets a file of the bundled renderers.
+func isRendererURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "file" {
+		return false
+	}
+	return strings.HasPrefix(filepath.Clean(u.Path), renderersPath+"/")
+}
+
+/*
+normalizeURL returns given URL the way
+Google Chrome requests it: an empty path
+becomes "/" and the path is percent-encoded.
+
+If the URL cannot be parsed, it is returned
+as is.
+*/
+func normalizeURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	if u.Path == "" && u.Host != "" {
+		u.Path = "/"
+	}
+	// forces the re-encoding of the path.
+	u.RawPath = ""
+	u.Fragment = ""
+	return u.String()
+}
+
+/*
+urlOrigin returns the origin of given URL.
+
+For file:// URLs, the origin is the
+directory of the file.
+*/
+func urlOrigin(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme == "file" {
+		dir := filepath.Dir(filepath.Clean(u.Path))
+		if dir == "/" {
+			return "file:///", nil
+		}
+		return fmt.Sprintf("file://%s/", dir), nil
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("'%s' has no origin", rawURL)
+	}
+	return fmt.Sprintf("%s://%s", u.Scheme, u.Host), nil
+}
+
+/*
+compileURLPatterns converts patterns using
+Google Chrome's wildcards ('*' matches zero or
+more characters, '?' exactly one) to regular
+expressions.
+*/
+func compileURLPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	result := make([]*regexp.Regexp, len(patterns))
+	for i, pattern := range patterns {
+		expr := regexp.QuoteMeta(pattern)
+		expr = strings.ReplaceAll(expr, `\*`, ".*")
+		expr = strings.ReplaceAll(expr, `\?`, ".")
+		re, err := regexp.Compile(fmt.Sprintf("^%s$", expr))
+		if err != nil {
+			return nil, err
+		}
+		result[i] = re
+	}
+	return result, nil
+}
+
+func matchAny(patterns []*regexp.Regexp, rawURL string) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(rawURL) {
+			return true
+		}
+	}
+	return false
+}
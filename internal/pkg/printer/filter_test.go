@@ -0,0 +1,75 @@
+package printer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
+)
+
+func TestURLFilter(t *testing.T) {
+	config := conf.DefaultConfig()
+	// no URL patterns nor restriction.
+	opts := DefaultChromePrinterOptions(config)
+	f, err := newURLFilter("https://example.com", opts)
+	assert.Nil(t, err)
+	assert.Equal(t, false, f.enabled())
+	assert.Equal(t, true, f.allow("https://google.com/foo.js"))
+	// blocked URL patterns.
+	opts = DefaultChromePrinterOptions(config)
+	opts.GlobalBlockedURLPatterns = []string{"*://*.doubleclick.net/*"}
+	opts.BlockedURLPatterns = []string{"*.mp?"}
+	f, err = newURLFilter("https://example.com", opts)
+	assert.Nil(t, err)
+	assert.Equal(t, true, f.enabled())
+	assert.Equal(t, true, f.allow("https://example.com"))
+	assert.Equal(t, true, f.allow("https://example.com/foo.js"))
+	assert.Equal(t, false, f.allow("https://ad.doubleclick.net/foo.js"))
+	assert.Equal(t, false, f.allow("https://example.com/foo.mp4"))
+	assert.Equal(t, false, f.allow("https://example.com/foo.mp3"))
+	// allowed URL patterns.
+	opts = DefaultChromePrinterOptions(config)
+	opts.GlobalAllowedURLPatterns = []string{"https://*"}
+	opts.AllowedURLPatterns = []string{"https://example.com/*", "https://cdn.example.com/*"}
+	f, err = newURLFilter("https://example.com", opts)
+	assert.Nil(t, err)
+	assert.Equal(t, true, f.allow("https://example.com"))
+	assert.Equal(t, true, f.allow("https://example.com/foo.js"))
+	assert.Equal(t, true, f.allow("https://cdn.example.com/foo.js"))
+	assert.Equal(t, false, f.allow("http://example.com/foo.js"))
+	assert.Equal(t, false, f.allow("https://google.com/foo.js"))
+	assert.Equal(t, true, f.allow("data:image/png;base64,foo"))
+	// the document is allowed whatever its
+	// URL as requested by Google Chrome.
+	opts = DefaultChromePrinterOptions(config)
+	opts.AllowedURLPatterns = []string{"https://cdn.example.com/*"}
+	f, err = newURLFilter("https://example.com", opts)
+	assert.Nil(t, err)
+	assert.Equal(t, true, f.allow("https://example.com/"))
+	assert.Equal(t, false, f.allow("https://example.com/foo.js"))
+	f, err = newURLFilter("file:///tmp/foo bar/index.html", opts)
+	assert.Nil(t, err)
+	assert.Equal(t, true, f.allow("file:///tmp/foo%20bar/index.html"))
+	assert.Equal(t, false, f.allow("file:///tmp/foo%20bar/style.css"))
+	// restricted to the document origin.
+	opts = DefaultChromePrinterOptions(config)
+	opts.RestrictToDocumentOrigin = true
+	f, err = newURLFilter("https://example.com/foo/index.html", opts)
+	assert.Nil(t, err)
+	assert.Equal(t, true, f.enabled())
+	assert.Equal(t, true, f.allow("https://example.com/foo.js"))
+	assert.Equal(t, false, f.allow("https://example.com:8080/foo.js"))
+	assert.Equal(t, false, f.allow("http://example.com/foo.js"))
+	assert.Equal(t, false, f.allow("https://cdn.example.com/foo.js"))
+	f, err = newURLFilter("file:///tmp/foo/index.html", opts)
+	assert.Nil(t, err)
+	assert.Equal(t, true, f.allow("file:///tmp/foo/index.html"))
+	assert.Equal(t, true, f.allow("file:///tmp/foo/style.css"))
+	assert.Equal(t, true, f.allow("file:///tmp/foo/img/logo.png"))
+	assert.Equal(t, false, f.allow("file:///tmp/foobar/style.css"))
+	assert.Equal(t, false, f.allow("file:///tmp/foo/../bar/style.css"))
+	assert.Equal(t, false, f.allow("file:///etc/passwd"))
+	assert.Equal(t, false, f.allow("https://example.com/foo.js"))
+	assert.Equal(t, true, f.allow("file:///opt/gotenberg/renderers/katex/katex.min.js"))
+	assert.Equal(t, false, f.allow("file:///opt/gotenberg/renderers/../../../etc/passwd"))
+}
@@ -0,0 +1,75 @@
+package printer
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"gopkg.in/yaml.v3"
+)
+
+/*
+splitFrontMatter returns the YAML front matter
+of given Markdown file and its remaining content.
+
+The front matter is delimited by two "---" lines
+at the very beginning of the file. Without a closing
+delimiter, there is no front matter (the first line
+is a thematic break).
+*/
+func splitFrontMatter(filename string, b []byte) (map[string]interface{}, []byte, error) {
+	const op string = "printer.splitFrontMatter"
+	meta := make(map[string]interface{})
+	content := bytes.TrimPrefix(b, []byte("\xef\xbb\xbf"))
+	lines := bytes.SplitAfter(content, []byte("\n"))
+	if len(lines) == 0 || string(bytes.TrimRight(lines[0], " \t\r\n")) != "---" {
+		return meta, b, nil
+	}
+	offset := len(lines[0])
+	for _, line := range lines[1:] {
+		delimiter := string(bytes.TrimRight(line, " \t\r\n"))
+		if delimiter != "---" && delimiter != "..." {
+			offset += len(line)
+			continue
+		}
+		if err := yaml.Unmarshal(content[len(lines[0]):offset], &meta); err != nil {
+			return nil, nil, xerror.Invalid(
+				op,
+				fmt.Sprintf("'%s' has an invalid front matter: %s", filename, err.Error()),
+				err,
+			)
+		}
+		if meta == nil {
+			meta = make(map[string]interface{})
+		}
+		return meta, content[offset+len(line):], nil
+	}
+	return meta, b, nil
+}
+
+/*
+frontMatterMetadata returns the PDF metadata
+from given front matter: its title and its author.
+
+A list of authors is joined with commas.
+*/
+func frontMatterMetadata(meta map[string]interface{}) map[string]string {
+	metadata := make(map[string]string)
+	for key, infoKey := range map[string]string{"title": "Title", "author": "Author"} {
+		value, ok := meta[key]
+		if !ok || value == nil {
+			continue
+		}
+		if values, ok := value.([]interface{}); ok {
+			authors := make([]string, len(values))
+			for i, v := range values {
+				authors[i] = fmt.Sprint(v)
+			}
+			metadata[infoKey] = strings.Join(authors, ", ")
+			continue
+		}
+		metadata[infoKey] = fmt.Sprint(value)
+	}
+	return metadata
+}
@@ -0,0 +1,57 @@
+package printer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/test"
+)
+
+func TestSplitFrontMatter(t *testing.T) {
+	// front matter.
+	meta, content, err := splitFrontMatter("a.md", []byte("---\ntitle: Foo\nauthors:\n  - Jane\n---\n# Foo\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"title": "Foo", "authors": []interface{}{"Jane"}}, meta)
+	assert.Equal(t, "# Foo\n", string(content))
+	// front matter with CRLF line endings,
+	// a BOM and the "..." closing delimiter.
+	meta, content, err = splitFrontMatter("a.md", []byte("\xef\xbb\xbf---\r\ntitle: Foo\r\n...\r\nBar"))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"title": "Foo"}, meta)
+	assert.Equal(t, "Bar", string(content))
+	// empty front matter.
+	meta, content, err = splitFrontMatter("a.md", []byte("---\n---\nBar"))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{}, meta)
+	assert.Equal(t, "Bar", string(content))
+	// no front matter.
+	meta, content, err = splitFrontMatter("a.md", []byte("# Foo\n---\nbar: baz\n---\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{}, meta)
+	assert.Equal(t, "# Foo\n---\nbar: baz\n---\n", string(content))
+	// thematic break without closing delimiter.
+	meta, content, err = splitFrontMatter("a.md", []byte("---\nFoo\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{}, meta)
+	assert.Equal(t, "---\nFoo\n", string(content))
+	// should not be OK as the front
+	// matter is not valid YAML.
+	_, _, err = splitFrontMatter("a.md", []byte("---\ntitle: [Foo\n---\n"))
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+}
+
+func TestFrontMatterMetadata(t *testing.T) {
+	meta := map[string]interface{}{
+		"title":  "Foo",
+		"author": []interface{}{"Jane", "John"},
+		"date":   "2020-01-02",
+	}
+	expected := map[string]string{
+		"Title":  "Foo",
+		"Author": "Jane, John",
+	}
+	assert.Equal(t, expected, frontMatterMetadata(meta))
+	assert.Equal(t, map[string]string{}, frontMatterMetadata(nil))
+}
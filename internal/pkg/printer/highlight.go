@@ -0,0 +1,93 @@
+package printer
+
+import (
+	"html"
+	"strings"
+
+	"github.com/alecthomas/chroma"
+	"github.com/alecthomas/chroma/lexers"
+)
+
+// lookupLexer returns the lexer of the
+// language of given info string, if any.
+func lookupLexer(info string) (chroma.Lexer, bool) {
+	fields := strings.Fields(info)
+	if len(fields) == 0 {
+		return nil, false
+	}
+	lexer := lexers.Get(strings.ToLower(fields[0]))
+	if lexer == nil {
+		return nil, false
+	}
+	return chroma.Coalesce(lexer), true
+}
+
+/*
+highlight returns the HTML of given code, where
+the tokens are wrapped in span elements with the
+classes "hl-keyword", "hl-literal", "hl-string",
+"hl-number", "hl-comment" and "hl-function".
+
+The tokens are given by the lexers of Chroma,
+but we keep our own classes so that the
+themes do not depend on its styles.
+*/
+func highlight(lexer chroma.Lexer, code string) (string, error) {
+	iterator, err := lexer.Tokenise(nil, code)
+	if err != nil {
+		return "", err
+	}
+	var (
+		b     strings.Builder
+		class string
+		value strings.Builder
+	)
+	flush := func() {
+		if value.Len() == 0 {
+			return
+		}
+		if class == "" {
+			b.WriteString(html.EscapeString(value.String()))
+		} else {
+			b.WriteString(`<span class="hl-`)
+			b.WriteString(class)
+			b.WriteString(`">`)
+			b.WriteString(html.EscapeString(value.String()))
+			b.WriteString(`</span>`)
+		}
+		value.Reset()
+	}
+	for _, token := range iterator.Tokens() {
+		// consecutive tokens of the same
+		// class share the same span element.
+		if c := tokenClass(token.Type); c != class {
+			flush()
+			class = c
+		}
+		value.WriteString(token.Value)
+	}
+	flush()
+	return b.String(), nil
+}
+
+// tokenClass returns the class of given
+// token type, or an empty string if the
+// token is not highlighted.
+func tokenClass(tokenType chroma.TokenType) string {
+	switch {
+	case tokenType.InCategory(chroma.Comment):
+		return "comment"
+	case tokenType == chroma.KeywordConstant:
+		return "literal"
+	case tokenType.InCategory(chroma.Keyword):
+		return "keyword"
+	case tokenType.InSubCategory(chroma.LiteralString):
+		return "string"
+	case tokenType.InSubCategory(chroma.LiteralNumber):
+		return "number"
+	case tokenType == chroma.NameFunction, tokenType == chroma.NameBuiltin:
+		return "function"
+	default:
+		return ""
+	}
+}
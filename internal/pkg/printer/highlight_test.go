@@ -0,0 +1,102 @@
+package printer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupLexer(t *testing.T) {
+	lexer, ok := lookupLexer("Go {.numberLines}")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "Go", lexer.Config().Name)
+	lexer, ok = lookupLexer("js")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "JavaScript", lexer.Config().Name)
+	_, ok = lookupLexer("foo")
+	assert.Equal(t, false, ok)
+	_, ok = lookupLexer("")
+	assert.Equal(t, false, ok)
+}
+
+func TestHighlight(t *testing.T) {
+	for _, tc := range []struct {
+		lang     string
+		code     string
+		expected []string
+	}{
+		{
+			"go",
+			"x := len(\"a\\\"b\") + 0x1F // <done>\n",
+			[]string{
+				`<span class="hl-function">len</span>`,
+				`<span class="hl-string">&#34;a\&#34;b&#34;</span>`,
+				`<span class="hl-number">0x1F</span>`,
+				`<span class="hl-comment">// &lt;done&gt;`,
+			},
+		},
+		{
+			"go",
+			"/* a\nb */ var s = nil\n",
+			[]string{
+				`<span class="hl-comment">/* a` + "\n" + `b */</span>`,
+				`<span class="hl-keyword">var</span>`,
+				`<span class="hl-literal">nil</span>`,
+			},
+		},
+		{
+			"python",
+			"def f():\n    return None # done\n",
+			[]string{
+				`<span class="hl-keyword">def</span>`,
+				`<span class="hl-function">f</span>`,
+				`<span class="hl-keyword">return</span>`,
+				`<span class="hl-literal">None</span>`,
+				`<span class="hl-comment"># done</span>`,
+			},
+		},
+		{
+			"javascript",
+			"const s = 'a' // done\n",
+			[]string{
+				`<span class="hl-keyword">const</span>`,
+				`<span class="hl-string">&#39;a&#39;</span>`,
+				`<span class="hl-comment">// done`,
+			},
+		},
+		{
+			"sql",
+			"SELECT v1 FROM t -- all\n",
+			[]string{
+				`<span class="hl-keyword">SELECT</span>`,
+				`<span class="hl-keyword">FROM</span>`,
+				`<span class="hl-comment">-- all`,
+			},
+		},
+		{
+			"json",
+			`{"a": [1.5, null]}` + "\n",
+			[]string{
+				`<span class="hl-number">1.5</span>`,
+				`<span class="hl-literal">null</span>`,
+			},
+		},
+		{
+			"bash",
+			"if true; then echo 'a'; fi\n",
+			[]string{
+				`<span class="hl-keyword">if</span>`,
+				`<span class="hl-string">&#39;a&#39;</span>`,
+				`<span class="hl-keyword">fi</span>`,
+			},
+		},
+	} {
+		lexer, ok := lookupLexer(tc.lang)
+		assert.Equal(t, true, ok, tc.lang)
+		code, err := highlight(lexer, tc.code)
+		assert.Nil(t, err)
+		for _, expected := range tc.expected {
+			assert.Contains(t, code, expected, tc.lang)
+		}
+	}
+}
@@ -2,17 +2,71 @@ package printer
 
 import (
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xrand"
 )
 
 // NewHTMLPrinter returns a Printer which
 // is able to convert an HTML file to PDF.
 func NewHTMLPrinter(logger xlog.Logger, fpath string, opts ChromePrinterOptions) Printer {
 	URL := fmt.Sprintf("file://%s", fpath)
-	return chromePrinter{
-		logger: logger,
-		url:    URL,
-		opts:   opts,
+	return newChromePrinter(logger, URL, opts)
+}
+
+/*
+NewHTMLTemplatePrinter returns a Printer which
+is able to convert an HTML file to PDF, once
+executed as a Go template with given data.
+
+The header and footer templates are
+executed with the same data.
+*/
+func NewHTMLTemplatePrinter(logger xlog.Logger, fpath string, data interface{}, opts ChromePrinterOptions) (Printer, error) {
+	const op string = "printer.NewHTMLTemplatePrinter"
+	resolver := func() (string, error) {
+		b, err := ioutil.ReadFile(fpath)
+		if err != nil {
+			return "", err
+		}
+		logger.DebugOp(op, "executing the HTML templates...")
+		html, err := executeTemplate(filepath.Base(fpath), string(b), data)
+		if err != nil {
+			return "", err
+		}
+		v := &opts.HeaderFooterVariants
+		for name, content := range map[string]*string{
+			"header.html":       &opts.HeaderHTML,
+			"footer.html":       &opts.FooterHTML,
+			"header-first.html": &v.FirstHeaderHTML,
+			"header-odd.html":   &v.OddHeaderHTML,
+			"header-even.html":  &v.EvenHeaderHTML,
+			"footer-first.html": &v.FirstFooterHTML,
+			"footer-odd.html":   &v.OddFooterHTML,
+			"footer-even.html":  &v.EvenFooterHTML,
+		} {
+			if *content == "" {
+				continue
+			}
+			*content, err = executeTemplate(name, *content, data)
+			if err != nil {
+				return "", err
+			}
+		}
+		// the new file must be in the same directory,
+		// so that the relative paths still work.
+		dst := filepath.Join(filepath.Dir(fpath), fmt.Sprintf("%s.html", xrand.Get()))
+		if err := ioutil.WriteFile(dst, []byte(html), 0600); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("file://%s", dst), nil
+	}
+	URL, err := resolver()
+	if err != nil {
+		return chromePrinter{}, xerror.New(op, err)
 	}
+	return newChromePrinter(logger, URL, opts), nil
 }
@@ -47,6 +47,165 @@ func TestHTMLPrinter(t *testing.T) {
 	assert.Nil(t, err)
 	err = os.RemoveAll(dest)
 	assert.Nil(t, err)
+	// options with screen media type
+	// and CSS page size.
+	opts = DefaultChromePrinterOptions(config)
+	opts.EmulatedMediaType = "screen"
+	opts.PreferCSSPageSize = true
+	p = NewHTMLPrinter(logger, fpath, opts)
+	dest = test.GenerateDestination()
+	err = p.Print(dest)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// options with a mobile device emulation.
+	opts = DefaultChromePrinterOptions(config)
+	opts.UserAgent = "Mozilla/5.0 (iPhone; CPU iPhone OS 13_2_3 like Mac OS X) Mobile/15E148"
+	opts.ViewportWidth = 375
+	opts.ViewportHeight = 812
+	opts.DeviceScaleFactor = 3.0
+	opts.IsMobile = true
+	p = NewHTMLPrinter(logger, fpath, opts)
+	dest = test.GenerateDestination()
+	err = p.Print(dest)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// options with a timezone and a locale.
+	opts = DefaultChromePrinterOptions(config)
+	opts.Timezone = "Europe/Paris"
+	opts.Locale = "fr-FR"
+	p = NewHTMLPrinter(logger, fpath, opts)
+	dest = test.GenerateDestination()
+	err = p.Print(dest)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// should not be OK as options have
+	// a wrong timezone.
+	opts = DefaultChromePrinterOptions(config)
+	opts.Timezone = "Foo/Bar"
+	p = NewHTMLPrinter(logger, fpath, opts)
+	dest = test.GenerateDestination()
+	err = p.Print(dest)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	// options with URL patterns and
+	// restricted to the document origin.
+	opts = DefaultChromePrinterOptions(config)
+	opts.BlockedURLPatterns = []string{"*.png"}
+	opts.RestrictToDocumentOrigin = true
+	p = NewHTMLPrinter(logger, fpath, opts)
+	dest = test.GenerateDestination()
+	err = p.Print(dest)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// options with JavaScript disabled.
+	opts = DefaultChromePrinterOptions(config)
+	opts.DisableJavaScript = true
+	p = NewHTMLPrinter(logger, fpath, opts)
+	dest = test.GenerateDestination()
+	err = p.Print(dest)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// options with a tagged PDF
+	// and a document outline.
+	opts = DefaultChromePrinterOptions(config)
+	opts.GenerateTaggedPDF = true
+	opts.GenerateDocumentOutline = true
+	p = NewHTMLPrinter(logger, fpath, opts)
+	dest = test.GenerateDestination()
+	err = p.Print(dest)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// options with header and footer variants.
+	opts = DefaultChromePrinterOptions(config)
+	opts.HeaderFooterVariants = HeaderFooterVariants{
+		FirstHeaderHTML: "<html><head></head><body><p>first</p></body></html>",
+		OddHeaderHTML:   "<html><head></head><body><p>odd</p></body></html>",
+		EvenFooterHTML:  "<html><head></head><body><p>even</p></body></html>",
+	}
+	p = NewHTMLPrinter(logger, fpath, opts)
+	dest = test.GenerateDestination()
+	err = p.Print(dest)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// template with JSON data.
+	opts = DefaultChromePrinterOptions(config)
+	opts.HeaderHTML = "<html><body><p>Invoice {{ .number }}</p></body></html>"
+	p, err = NewHTMLTemplatePrinter(logger, test.CopyFpaths(t, test.TemplateFpaths(t)...)[0], invoiceData(t), opts)
+	assert.Nil(t, err)
+	dest = test.GenerateDestination()
+	err = p.Print(dest)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// should not be OK as the
+	// header template is invalid.
+	opts = DefaultChromePrinterOptions(config)
+	opts.HeaderHTML = "<html><body><p>Invoice {{ .number </p></body></html>"
+	_, err = NewHTMLTemplatePrinter(logger, test.CopyFpaths(t, test.TemplateFpaths(t)...)[0], invoiceData(t), opts)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	// options with a table of contents
+	// and a page ranges.
+	opts = DefaultChromePrinterOptions(config)
+	opts.TableOfContents = true
+	opts.PageRanges = "1-2"
+	p = NewHTMLPrinter(logger, fpath, opts)
+	dest = test.GenerateDestination()
+	err = p.Print(dest)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// options with a readiness expression
+	// and selector.
+	opts = DefaultChromePrinterOptions(config)
+	opts.WaitForExpression = "document.readyState === 'complete'"
+	opts.WaitForSelector = "body"
+	p = NewHTMLPrinter(logger, fpath, opts)
+	dest = test.GenerateDestination()
+	err = p.Print(dest)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// should not be OK as the readiness
+	// expression is never true.
+	opts = DefaultChromePrinterOptions(config)
+	opts.WaitTimeout = 2.0
+	opts.WaitForExpression = "window.status === 'ready'"
+	p = NewHTMLPrinter(logger, fpath, opts)
+	dest = test.GenerateDestination()
+	err = p.Print(dest)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.TimeoutCode, xerror.Code(err))
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// options with console exceptions
+	// but without failing on them.
+	opts = DefaultChromePrinterOptions(config)
+	p = NewHTMLPrinter(logger, test.JavaScriptFpaths(t)[0], opts)
+	dest = test.GenerateDestination()
+	err = p.Print(dest)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, p.(ConsoleReporter).ConsoleMessages())
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// should not be OK as the page
+	// throws an exception.
+	opts = DefaultChromePrinterOptions(config)
+	opts.FailOnConsoleExceptions = true
+	p = NewHTMLPrinter(logger, test.JavaScriptFpaths(t)[0], opts)
+	dest = test.GenerateDestination()
+	err = p.Print(dest)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
 	// should not be OK as options have
 	// a wrong page ranges.
 	opts = DefaultChromePrinterOptions(config)
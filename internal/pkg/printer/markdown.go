@@ -3,39 +3,110 @@ package printer
 import (
 	"bytes"
 	"fmt"
+	"html"
+	"html/template"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
 	"github.com/microcosm-cc/bluemonday"
 	"github.com/russross/blackfriday/v2"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
 	"github.com/thecodingmachine/gotenberg/internal/pkg/xrand"
-	"html/template"
-	"io/ioutil"
-	"path/filepath"
 )
 
+// MarkdownPrinterOptions helps customizing the
+// Markdown Printer behaviour.
+type MarkdownPrinterOptions struct {
+	Chrome       ChromePrinterOptions
+	Theme        string
+	Sanitization string
+	Files        []string
+	PageBreaks   bool
+}
+
+// DefaultMarkdownPrinterOptions returns the default
+// Markdown Printer options.
+func DefaultMarkdownPrinterOptions(config conf.Config) MarkdownPrinterOptions {
+	return MarkdownPrinterOptions{
+		Chrome:       DefaultChromePrinterOptions(config),
+		Theme:        "none",
+		Sanitization: config.DefaultMarkdownSanitizationProfile(),
+		Files:        nil,
+		PageBreaks:   false,
+	}
+}
+
 // NewMarkdownPrinter returns a Printer which
 // is able to convert Markdown files to PDF.
-func NewMarkdownPrinter(logger xlog.Logger, fpath string, opts ChromePrinterOptions) (Printer, error) {
+func NewMarkdownPrinter(logger xlog.Logger, fpath string, opts MarkdownPrinterOptions) (Printer, error) {
 	const op string = "printer.NewMarkdownPrinter"
+	chromeOpts := opts.Chrome
 	resolver := func() (string, error) {
-		tmpl, err := template.
-			New(filepath.Base(fpath)).
-			Funcs(template.FuncMap{"toHTML": markdownToHTML}).
-			ParseFiles(fpath)
-		if err != nil {
-			return "", err
+		toc := func() template.HTML {
+			// the table of contents is built
+			// by Google Chrome, once the page
+			// is loaded.
+			chromeOpts.TableOfContents = true
+			return tocPlaceholder
 		}
 		dirPath := filepath.Dir(fpath)
-		data := &templateData{DirPath: dirPath}
+		data := &templateData{DirPath: dirPath, sanitization: opts.Sanitization}
+		tmpl := template.
+			New(filepath.Base(fpath)).
+			Funcs(template.FuncMap{"toHTML": data.toHTML, "toc": toc})
+		if _, err := os.Stat(fpath); os.IsNotExist(err) {
+			logger.DebugOp(op, "no template provided, using the default document...")
+			filenames, err := markdownFiles(dirPath, opts.Files)
+			if err != nil {
+				return "", err
+			}
+			data.Files = filenames
+			data.PageBreaks = opts.PageBreaks
+			tmpl, err = tmpl.Parse(defaultMarkdownTemplate)
+			if err != nil {
+				return "", err
+			}
+		} else {
+			tmpl, err = tmpl.ParseFiles(fpath)
+			if err != nil {
+				return "", err
+			}
+		}
 		logger.DebugOp(op, "converting Markdown files to HTML...")
 		var buffer bytes.Buffer
 		if err := tmpl.Execute(&buffer, data); err != nil {
+			// the template package does not
+			// keep the errors of the functions.
+			if data.err != nil {
+				return "", data.err
+			}
 			return "", err
 		}
+		// the front matter of the first Markdown
+		// file provides the PDF metadata.
+		if metadata := frontMatterMetadata(data.firstMeta); len(metadata) > 0 {
+			chromeOpts.Metadata = metadata
+		}
+		result := applyMarkdownTheme(opts.Theme, buffer.String())
+		// the renderers need JavaScript.
+		if !chromeOpts.DisableJavaScript {
+			var ok bool
+			result, ok = applyRenderers(result)
+			if ok {
+				chromeOpts.WaitForExpression = renderersReadiness(chromeOpts.WaitForExpression)
+			}
+		}
 		baseFilename := xrand.Get()
 		dst := fmt.Sprintf("%s/%s.html", dirPath, baseFilename)
 		logger.DebugOp(op, "writing the HTML from previous conversion(s) into new file...")
-		if err := ioutil.WriteFile(dst, buffer.Bytes(), 0600); err != nil {
+		if err := ioutil.WriteFile(dst, []byte(result), 0600); err != nil {
 			return "", err
 		}
 		return fmt.Sprintf("file://%s", dst), nil
@@ -44,28 +115,323 @@ func NewMarkdownPrinter(logger xlog.Logger, fpath string, opts ChromePrinterOpti
 	if err != nil {
 		return chromePrinter{}, xerror.New(op, err)
 	}
-	return chromePrinter{
-		logger: logger,
-		url:    URL,
-		opts:   opts,
-	}, nil
+	return newChromePrinter(logger, URL, chromeOpts), nil
 }
 
+/*
+templateData is the data of the template
+which includes the Markdown files.
+*/
 type templateData struct {
 	DirPath string
+	// Files are the Markdown files of
+	// the default document, in order.
+	Files []string
+	// PageBreaks adds a page break between
+	// the files of the default document.
+	PageBreaks bool
+	// firstMeta is the front matter of
+	// the first included Markdown file.
+	firstMeta map[string]interface{}
+	// sanitization is the sanitization profile
+	// of the HTML from the Markdown files.
+	sanitization string
+	err          error
 }
 
-func markdownToHTML(dirPath, filename string) (template.HTML, error) {
-	const op string = "printer.markdownToHTML"
-	// avoid directory traversal.
-	filename = filepath.Base(filename)
-	fpath := fmt.Sprintf("%s/%s", dirPath, filename)
-	b, err := ioutil.ReadFile(fpath)
+/*
+Meta returns the value of given key from
+the front matter of given Markdown file,
+or nil if there is no such key.
+*/
+func (data *templateData) Meta(filename, key string) (interface{}, error) {
+	const op string = "printer.templateData.Meta"
+	meta, _, err := readMarkdownFile(data.DirPath, filename)
+	if err != nil {
+		data.err = xerror.New(op, err)
+		return nil, data.err
+	}
+	return meta[key], nil
+}
+
+// toHTML converts given Markdown file to HTML,
+// without its front matter.
+func (data *templateData) toHTML(dirPath, filename string) (template.HTML, error) {
+	const op string = "printer.templateData.toHTML"
+	meta, b, err := readMarkdownFile(dirPath, filename)
 	if err != nil {
-		return "", xerror.New(op, err)
+		data.err = xerror.New(op, err)
+		return "", data.err
 	}
-	unsafe := blackfriday.Run(b)
-	content := bluemonday.UGCPolicy().SanitizeBytes(unsafe)
+	if data.firstMeta == nil {
+		data.firstMeta = meta
+	}
+	// the formulas are not Markdown.
+	b, formulas := extractMath(b)
+	content := renderMarkdown(b)
+	if policy := markdownPolicy(data.sanitization); policy != nil {
+		content = policy.SanitizeBytes(content)
+	}
+	content = restoreMath(content, formulas)
 	/* #nosec */
 	return template.HTML(content), nil
 }
+
+/*
+defaultMarkdownTemplate is the template used
+when there is no index.html file: it converts
+the Markdown files one after the other.
+
+Its title comes from the front matter of
+the first Markdown file, if any.
+*/
+const defaultMarkdownTemplate string = `<!doctype html>
+<html>
+<head>
+<meta charset="utf-8">
+{{ with .Meta (index .Files 0) "title" }}<title>{{ . }}</title>{{ end }}
+<style>.markdown-file.page-break { page-break-before: always; }</style>
+</head>
+<body>
+{{ range $i, $file := .Files }}
+<section class="markdown-file{{ if and $.PageBreaks $i }} page-break{{ end }}">
+{{ toHTML $.DirPath $file }}
+</section>
+{{ end }}
+</body>
+</html>`
+
+/*
+markdownFiles returns the Markdown files of
+the default document: either the given ones,
+or all the .md files of given directory
+(subdirectories included) in alphabetical order.
+
+It returns an error if there is no such file.
+*/
+func markdownFiles(dirPath string, filenames []string) ([]string, error) {
+	const op string = "printer.markdownFiles"
+	resolver := func() ([]string, error) {
+		if len(filenames) > 0 {
+			return filenames, nil
+		}
+		var result []string
+		err := filepath.Walk(dirPath, func(fpath string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() || strings.ToLower(filepath.Ext(fpath)) != ".md" {
+				return nil
+			}
+			filename, err := filepath.Rel(dirPath, fpath)
+			if err != nil {
+				return err
+			}
+			result = append(result, filepath.ToSlash(filename))
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(result) == 0 {
+			return nil, xerror.Invalid(
+				op,
+				"no index.html nor Markdown files found",
+				nil,
+			)
+		}
+		sort.Strings(result)
+		return result, nil
+	}
+	result, err := resolver()
+	if err != nil {
+		return nil, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+// readMarkdownFile returns the front matter
+// and the content of given Markdown file.
+func readMarkdownFile(dirPath, filename string) (map[string]interface{}, []byte, error) {
+	const op string = "printer.readMarkdownFile"
+	// avoid directory traversal, while allowing
+	// the files from subdirectories (archives).
+	fpath := filepath.Join(dirPath, filepath.Clean("/"+filename))
+	b, err := ioutil.ReadFile(fpath)
+	if err != nil {
+		return nil, nil, xerror.New(op, err)
+	}
+	meta, content, err := splitFrontMatter(filename, b)
+	if err != nil {
+		return nil, nil, xerror.New(op, err)
+	}
+	return meta, content, nil
+}
+
+/*
+renderMarkdown converts given Markdown to HTML,
+with the GitHub-flavored extensions: tables,
+task lists, footnotes, heading IDs and
+syntax highlighting of fenced code blocks.
+*/
+func renderMarkdown(b []byte) []byte {
+	renderer := markdownRenderer{
+		HTMLRenderer: blackfriday.NewHTMLRenderer(blackfriday.HTMLRendererParameters{
+			Flags: blackfriday.CommonHTMLFlags | blackfriday.FootnoteReturnLinks,
+		}),
+	}
+	unsafe := blackfriday.Run(
+		b,
+		blackfriday.WithRenderer(renderer),
+		blackfriday.WithExtensions(
+			blackfriday.CommonExtensions|
+				blackfriday.Footnotes|
+				blackfriday.AutoHeadingIDs,
+		),
+	)
+	return taskListItemRegexp.ReplaceAllFunc(unsafe, func(item []byte) []byte {
+		matches := taskListItemRegexp.FindSubmatch(item)
+		checkbox := `<input type="checkbox" disabled="disabled">`
+		if !bytes.Equal(matches[2], []byte(" ")) {
+			checkbox = `<input type="checkbox" checked="checked" disabled="disabled">`
+		}
+		return []byte(fmt.Sprintf(`<li class="task-list-item">%s%s `, matches[1], checkbox))
+	})
+}
+
+// nolint: gochecknoglobals
+var (
+	taskListItemRegexp  = regexp.MustCompile(`<li>(\s*<p>)?\[([ xX])\]\s`)
+	markdownClassRegexp = regexp.MustCompile(
+		`^(highlight|language-[\w+#-]+|hl-[a-z]+|task-list-item|footnotes|footnote-ref|footnote-return|mermaid)$`,
+	)
+)
+
+/*
+markdownPolicy returns the sanitization policy
+of the HTML from Markdown files for given profile,
+or nil if the HTML is not sanitized ("none").
+
+The "strict" profile only keeps the HTML of the
+Markdown syntax, "ugc" the HTML of user generated
+content, and "trusted" also keeps the classes,
+the styles, the iframes and the inline SVG.
+
+All of them keep the classes for the syntax
+highlighting, the task lists and the footnotes.
+*/
+func markdownPolicy(profile string) *bluemonday.Policy {
+	var p *bluemonday.Policy
+	switch profile {
+	case "none":
+		return nil
+	case "strict":
+		p = strictMarkdownPolicy()
+	case "trusted":
+		p = trustedMarkdownPolicy()
+	default:
+		p = bluemonday.UGCPolicy()
+	}
+	p.AllowAttrs("class").
+		Matching(markdownClassRegexp).
+		OnElements("pre", "code", "span", "li", "div", "sup", "a")
+	p.AllowAttrs("type").
+		Matching(regexp.MustCompile(`^checkbox$`)).
+		OnElements("input")
+	p.AllowAttrs("checked", "disabled").
+		Matching(regexp.MustCompile(`^(checked|disabled)?$`)).
+		OnElements("input")
+	return p
+}
+
+// strictMarkdownPolicy only keeps the
+// HTML of the Markdown syntax.
+func strictMarkdownPolicy() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	p.AllowStandardURLs()
+	p.AllowElements(
+		"p", "br", "hr", "h1", "h2", "h3", "h4", "h5", "h6",
+		"em", "strong", "del", "code", "pre", "blockquote",
+		"ul", "ol", "li", "table", "thead", "tbody", "tr", "th", "td", "sup",
+	)
+	p.AllowAttrs("id").
+		Matching(regexp.MustCompile(`^[\w:.-]+$`)).
+		OnElements("h1", "h2", "h3", "h4", "h5", "h6", "li", "sup")
+	p.AllowAttrs("href", "title").OnElements("a")
+	p.AllowAttrs("src", "alt", "title").OnElements("img")
+	p.AllowAttrs("start").Matching(bluemonday.Integer).OnElements("ol")
+	p.AllowAttrs("align").
+		Matching(regexp.MustCompile(`^(left|center|right)$`)).
+		OnElements("th", "td")
+	return p
+}
+
+// nolint: gochecknoglobals
+var (
+	svgElements = []string{
+		"svg", "g", "defs", "symbol", "use", "title", "desc",
+		"path", "circle", "ellipse", "line", "polyline", "polygon", "rect",
+		"text", "tspan", "textpath", "marker", "clippath", "mask", "pattern",
+		"lineargradient", "radialgradient", "stop",
+	}
+	// the HTML parser of the browser restores
+	// the case of the SVG attributes (e.g. viewBox).
+	svgAttributes = []string{
+		"xmlns", "version", "viewbox", "preserveaspectratio", "width", "height",
+		"x", "y", "x1", "y1", "x2", "y2", "dx", "dy", "cx", "cy", "r", "rx", "ry",
+		"d", "points", "transform", "href", "offset", "opacity",
+		"fill", "fill-opacity", "fill-rule", "stroke", "stroke-width", "stroke-opacity",
+		"stroke-linecap", "stroke-linejoin", "stroke-dasharray",
+		"font-family", "font-size", "font-weight", "text-anchor", "dominant-baseline",
+		"stop-color", "stop-opacity", "gradientunits", "gradienttransform",
+		"marker-start", "marker-mid", "marker-end", "markerwidth", "markerheight",
+		"refx", "refy", "orient", "clip-path", "mask", "patternunits",
+	}
+)
+
+// trustedMarkdownPolicy keeps the HTML of user
+// generated content, plus the classes, the styles,
+// the iframes and the inline SVG.
+func trustedMarkdownPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("class", "style").Globally()
+	p.AllowDataAttributes()
+	p.AllowAttrs("src", "width", "height", "frameborder", "allow", "allowfullscreen", "title").
+		OnElements("iframe")
+	p.AllowElements(svgElements...)
+	p.AllowAttrs(svgAttributes...).OnElements(svgElements...)
+	return p
+}
+
+// markdownRenderer highlights the fenced code
+// blocks of known languages.
+type markdownRenderer struct {
+	*blackfriday.HTMLRenderer
+}
+
+func (r markdownRenderer) RenderNode(w io.Writer, node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+	if node.Type != blackfriday.CodeBlock {
+		return r.HTMLRenderer.RenderNode(w, node, entering)
+	}
+	if fields := strings.Fields(string(node.Info)); len(fields) > 0 && strings.ToLower(fields[0]) == "mermaid" {
+		// rendered by Mermaid, once the page is loaded.
+		fmt.Fprintf(w, "<div class=\"mermaid\">%s</div>\n", html.EscapeString(string(node.Literal))) // nolint: errcheck
+		return blackfriday.GoToNext
+	}
+	lexer, ok := lookupLexer(string(node.Info))
+	if !ok {
+		return r.HTMLRenderer.RenderNode(w, node, entering)
+	}
+	code, err := highlight(lexer, string(node.Literal))
+	if err != nil {
+		return r.HTMLRenderer.RenderNode(w, node, entering)
+	}
+	name := strings.ToLower(strings.Fields(string(node.Info))[0])
+	fmt.Fprintf( // nolint: errcheck
+		w,
+		"<pre class=\"highlight\"><code class=\"language-%s\">%s</code></pre>\n",
+		html.EscapeString(name),
+		code,
+	)
+	return blackfriday.GoToNext
+}
@@ -1,7 +1,10 @@
 package printer
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -15,14 +18,14 @@ func TestMarkdownPrinter(t *testing.T) {
 	var (
 		logger xlog.Logger = test.DebugLogger()
 		config conf.Config = conf.DefaultConfig()
-		fpath  string      = test.MarkdownFpaths(t)[0]
-		opts   ChromePrinterOptions
+		fpath  string      = test.CopyFpaths(t, test.MarkdownFpaths(t)...)[0]
+		opts   MarkdownPrinterOptions
 		dest   string
 		p      Printer
 		err    error
 	)
 	// default options.
-	opts = DefaultChromePrinterOptions(config)
+	opts = DefaultMarkdownPrinterOptions(config)
 	p, err = NewMarkdownPrinter(logger, fpath, opts)
 	assert.Nil(t, err)
 	dest = test.GenerateDestination()
@@ -31,8 +34,8 @@ func TestMarkdownPrinter(t *testing.T) {
 	err = os.RemoveAll(dest)
 	assert.Nil(t, err)
 	// options with a wait delay.
-	opts = DefaultChromePrinterOptions(config)
-	opts.WaitDelay = 0.5
+	opts = DefaultMarkdownPrinterOptions(config)
+	opts.Chrome.WaitDelay = 0.5
 	p, err = NewMarkdownPrinter(logger, fpath, opts)
 	assert.Nil(t, err)
 	dest = test.GenerateDestination()
@@ -41,8 +44,40 @@ func TestMarkdownPrinter(t *testing.T) {
 	err = os.RemoveAll(dest)
 	assert.Nil(t, err)
 	// options with a page ranges.
-	opts = DefaultChromePrinterOptions(config)
-	opts.PageRanges = "1"
+	opts = DefaultMarkdownPrinterOptions(config)
+	opts.Chrome.PageRanges = "1"
+	p, err = NewMarkdownPrinter(logger, fpath, opts)
+	assert.Nil(t, err)
+	dest = test.GenerateDestination()
+	err = p.Print(dest)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// options with screen media type
+	// and CSS page size.
+	opts = DefaultMarkdownPrinterOptions(config)
+	opts.Chrome.EmulatedMediaType = "screen"
+	opts.Chrome.PreferCSSPageSize = true
+	p, err = NewMarkdownPrinter(logger, fpath, opts)
+	assert.Nil(t, err)
+	dest = test.GenerateDestination()
+	err = p.Print(dest)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// options with a theme.
+	opts = DefaultMarkdownPrinterOptions(config)
+	opts.Theme = "github"
+	p, err = NewMarkdownPrinter(logger, fpath, opts)
+	assert.Nil(t, err)
+	dest = test.GenerateDestination()
+	err = p.Print(dest)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// options with a table of contents.
+	opts = DefaultMarkdownPrinterOptions(config)
+	opts.Chrome.TableOfContents = true
 	p, err = NewMarkdownPrinter(logger, fpath, opts)
 	assert.Nil(t, err)
 	dest = test.GenerateDestination()
@@ -52,8 +87,8 @@ func TestMarkdownPrinter(t *testing.T) {
 	assert.Nil(t, err)
 	// should not be OK as options have
 	// a wrong page ranges.
-	opts = DefaultChromePrinterOptions(config)
-	opts.PageRanges = "foo"
+	opts = DefaultMarkdownPrinterOptions(config)
+	opts.Chrome.PageRanges = "foo"
 	p, err = NewMarkdownPrinter(logger, fpath, opts)
 	assert.Nil(t, err)
 	dest = test.GenerateDestination()
@@ -64,8 +99,8 @@ func TestMarkdownPrinter(t *testing.T) {
 	assert.Nil(t, err)
 	// should not be OK as context.Context
 	// should timeout.
-	opts = DefaultChromePrinterOptions(config)
-	opts.WaitTimeout = 0.0
+	opts = DefaultMarkdownPrinterOptions(config)
+	opts.Chrome.WaitTimeout = 0.0
 	p, err = NewMarkdownPrinter(logger, fpath, opts)
 	assert.Nil(t, err)
 	dest = test.GenerateDestination()
@@ -75,3 +110,248 @@ func TestMarkdownPrinter(t *testing.T) {
 	err = os.RemoveAll(dest)
 	assert.Nil(t, err)
 }
+
+func TestRenderMarkdown(t *testing.T) {
+	md := "# Title\n\n" +
+		"| a | b |\n|---|---|\n| 1 | 2 |\n\n" +
+		"- [ ] todo\n- [x] done\n\n" +
+		"Text[^1].\n\n[^1]: A note.\n\n" +
+		"```go\nreturn true\n```\n\n" +
+		"<script>alert(1)</script><span class=\"evil\">x</span>\n"
+	html := string(markdownPolicy("ugc").SanitizeBytes(renderMarkdown([]byte(md))))
+	assert.Contains(t, html, `<h1 id="title">Title</h1>`)
+	assert.Contains(t, html, "<td>1</td>")
+	assert.Contains(t, html, `<li class="task-list-item"><input type="checkbox" disabled="disabled"> todo</li>`)
+	assert.Contains(t, html, `<li class="task-list-item"><input type="checkbox" checked="checked" disabled="disabled"> done</li>`)
+	assert.Contains(t, html, `<sup class="footnote-ref" id="fnref:1">`)
+	assert.Contains(t, html, `<li id="fn:1">A note.`)
+	assert.Contains(t, html, `<pre class="highlight"><code class="language-go">`+
+		`<span class="hl-keyword">return</span> <span class="hl-literal">true</span>`)
+	assert.NotContains(t, html, "<script>")
+	assert.NotContains(t, html, "evil")
+}
+
+func TestMarkdownPolicy(t *testing.T) {
+	md := "# Title\n\n" +
+		"- [x] done\n\n" +
+		"<b style=\"color: red\">bold</b>\n\n" +
+		"<iframe src=\"https://example.com\"></iframe>\n\n" +
+		"<svg viewBox=\"0 0 10 10\"><circle cx=\"5\" cy=\"5\" r=\"4\"/></svg>\n\n" +
+		"<script>alert(1)</script>\n"
+	unsafe := renderMarkdown([]byte(md))
+	sanitize := func(profile string) string {
+		return string(markdownPolicy(profile).SanitizeBytes(unsafe))
+	}
+	// strict.
+	html := sanitize("strict")
+	assert.Contains(t, html, `<h1 id="title">Title</h1>`)
+	assert.Contains(t, html, `<li class="task-list-item"><input type="checkbox" checked="checked" disabled="disabled"> done</li>`)
+	assert.NotContains(t, html, "<b")
+	assert.NotContains(t, html, "<iframe")
+	assert.NotContains(t, html, "<svg")
+	assert.NotContains(t, html, "<script>")
+	// ugc.
+	html = sanitize("ugc")
+	assert.Contains(t, html, "<b>bold</b>")
+	assert.NotContains(t, html, "style")
+	assert.NotContains(t, html, "<iframe")
+	assert.NotContains(t, html, "<svg")
+	assert.NotContains(t, html, "<script>")
+	// trusted.
+	html = sanitize("trusted")
+	assert.Contains(t, html, `<b style="color: red">bold</b>`)
+	assert.Contains(t, html, `<iframe src="https://example.com"></iframe>`)
+	assert.Contains(t, html, `<svg viewbox="0 0 10 10"><circle cx="5" cy="5" r="4"/></svg>`)
+	assert.NotContains(t, html, "<script>")
+	// none.
+	assert.Nil(t, markdownPolicy("none"))
+}
+
+func TestMarkdownPrinterExtensions(t *testing.T) {
+	fpath := test.CopyFpaths(t, test.MarkdownFpath(t, "gfm.md"))[0]
+	opts := DefaultMarkdownPrinterOptions(conf.DefaultConfig())
+	// without index.html, the default
+	// document includes "gfm.md".
+	p, err := NewMarkdownPrinter(test.DebugLogger(), filepath.Join(filepath.Dir(fpath), "index.html"), opts)
+	assert.Nil(t, err)
+	b, err := ioutil.ReadFile(strings.TrimPrefix(p.(chromePrinter).url, "file://"))
+	assert.Nil(t, err)
+	html := string(b)
+	assert.Contains(t, html, "<td>Printing press</td>")
+	assert.Contains(t, html, `<li class="task-list-item"><input type="checkbox" checked="checked" disabled="disabled"> Cast the movable type</li>`)
+	assert.Contains(t, html, `<sup class="footnote-ref" id="fnref:1">`)
+	assert.Contains(t, html, `<pre class="highlight"><code class="language-go"><span class="hl-comment">// print prints the Bible.`)
+	assert.Contains(t, html, `<span class="hl-string">&#34;%d pages left&#34;</span>`)
+}
+
+func TestMarkdownPrinterSanitization(t *testing.T) {
+	dirPath, err := ioutil.TempDir("", "sanitization")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dirPath) // nolint: errcheck
+	fpath := filepath.Join(dirPath, "index.html")
+	err = ioutil.WriteFile(fpath, []byte(`{{ toHTML .DirPath "a.md" }}`), 0600)
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(filepath.Join(dirPath, "a.md"), []byte("<script>alert(1)</script>\n"), 0600)
+	assert.Nil(t, err)
+	read := func(p Printer) string {
+		b, err := ioutil.ReadFile(strings.TrimPrefix(p.(chromePrinter).url, "file://"))
+		assert.Nil(t, err)
+		return string(b)
+	}
+	opts := DefaultMarkdownPrinterOptions(conf.DefaultConfig())
+	assert.Equal(t, "ugc", opts.Sanitization)
+	p, err := NewMarkdownPrinter(test.DebugLogger(), fpath, opts)
+	assert.Nil(t, err)
+	assert.NotContains(t, read(p), "<script>")
+	// without sanitization.
+	opts.Sanitization = "none"
+	p, err = NewMarkdownPrinter(test.DebugLogger(), fpath, opts)
+	assert.Nil(t, err)
+	assert.Contains(t, read(p), "<script>alert(1)</script>")
+}
+
+func TestMarkdownPrinterDefaultDocument(t *testing.T) {
+	dirPath, err := ioutil.TempDir("", "default")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dirPath) // nolint: errcheck
+	fpath := filepath.Join(dirPath, "index.html")
+	read := func(p Printer) string {
+		b, err := ioutil.ReadFile(strings.TrimPrefix(p.(chromePrinter).url, "file://"))
+		assert.Nil(t, err)
+		return string(b)
+	}
+	opts := DefaultMarkdownPrinterOptions(conf.DefaultConfig())
+	// should not be OK as there
+	// are no Markdown files.
+	_, err = NewMarkdownPrinter(test.DebugLogger(), fpath, opts)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	err = ioutil.WriteFile(filepath.Join(dirPath, "b.md"), []byte("---\ntitle: Foo\n---\n# B\n"), 0600)
+	assert.Nil(t, err)
+	err = os.Mkdir(filepath.Join(dirPath, "chapters"), 0700)
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(filepath.Join(dirPath, "chapters", "a.md"), []byte("# A\n"), 0600)
+	assert.Nil(t, err)
+	// all the Markdown files, in
+	// alphabetical order.
+	p, err := NewMarkdownPrinter(test.DebugLogger(), fpath, opts)
+	assert.Nil(t, err)
+	html := read(p)
+	assert.Contains(t, html, "<title>Foo</title>")
+	assert.Less(t, strings.Index(html, `<h1 id="b">B</h1>`), strings.Index(html, `<h1 id="a">A</h1>`))
+	assert.NotContains(t, html, `markdown-file page-break`)
+	assert.Equal(t, "Foo", p.(chromePrinter).opts.Metadata["Title"])
+	// given Markdown files,
+	// with page breaks.
+	opts.Files = []string{"chapters/a.md", "b.md"}
+	opts.PageBreaks = true
+	p, err = NewMarkdownPrinter(test.DebugLogger(), fpath, opts)
+	assert.Nil(t, err)
+	html = read(p)
+	assert.NotContains(t, html, "<title>")
+	assert.Less(t, strings.Index(html, `<h1 id="a">A</h1>`), strings.Index(html, `<h1 id="b">B</h1>`))
+	assert.Equal(t, 1, strings.Count(html, `<section class="markdown-file page-break">`))
+	// should not be OK as a given
+	// Markdown file does not exist.
+	opts.Files = []string{"c.md"}
+	_, err = NewMarkdownPrinter(test.DebugLogger(), fpath, opts)
+	test.AssertError(t, err)
+}
+
+func TestMarkdownPrinterTOC(t *testing.T) {
+	dirPath, err := ioutil.TempDir("", "toc")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dirPath) // nolint: errcheck
+	fpath := filepath.Join(dirPath, "index.html")
+	err = ioutil.WriteFile(fpath, []byte(`<html><head></head><body>{{ toc }}{{ toHTML .DirPath "a.md" }}</body></html>`), 0600)
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(filepath.Join(dirPath, "a.md"), []byte("# Title\n"), 0600)
+	assert.Nil(t, err)
+	opts := DefaultMarkdownPrinterOptions(conf.DefaultConfig())
+	p, err := NewMarkdownPrinter(test.DebugLogger(), fpath, opts)
+	assert.Nil(t, err)
+	chrome := p.(chromePrinter)
+	assert.Equal(t, true, chrome.opts.TableOfContents)
+	b, err := ioutil.ReadFile(strings.TrimPrefix(chrome.url, "file://"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(b), string(tocPlaceholder)+`<h1 id="title">Title</h1>`)
+	// without the "toc" function.
+	err = ioutil.WriteFile(fpath, []byte(`{{ toHTML .DirPath "a.md" }}`), 0600)
+	assert.Nil(t, err)
+	p, err = NewMarkdownPrinter(test.DebugLogger(), fpath, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, false, p.(chromePrinter).opts.TableOfContents)
+}
+
+func TestMarkdownPrinterFrontMatter(t *testing.T) {
+	dirPath, err := ioutil.TempDir("", "frontmatter")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dirPath) // nolint: errcheck
+	fpath := filepath.Join(dirPath, "index.html")
+	err = ioutil.WriteFile(
+		fpath,
+		[]byte(`<title>{{ .Meta "a.md" "title" }}</title>{{ toHTML .DirPath "a.md" }}{{ toHTML .DirPath "b.md" }}{{ .Meta "b.md" "foo" }}`),
+		0600,
+	)
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(filepath.Join(dirPath, "a.md"), []byte("---\ntitle: Foo\nauthor: Jane\n---\nA\n"), 0600)
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(filepath.Join(dirPath, "b.md"), []byte("---\ntitle: Bar\n---\nB\n"), 0600)
+	assert.Nil(t, err)
+	opts := DefaultMarkdownPrinterOptions(conf.DefaultConfig())
+	p, err := NewMarkdownPrinter(test.DebugLogger(), fpath, opts)
+	assert.Nil(t, err)
+	chrome := p.(chromePrinter)
+	assert.Equal(t, map[string]string{"Title": "Foo", "Author": "Jane"}, chrome.opts.Metadata)
+	b, err := ioutil.ReadFile(strings.TrimPrefix(chrome.url, "file://"))
+	assert.Nil(t, err)
+	assert.Equal(t, "<title>Foo</title><p>A</p>\n<p>B</p>\n", string(b))
+	// should not be OK as the front
+	// matter is not valid YAML.
+	err = ioutil.WriteFile(filepath.Join(dirPath, "b.md"), []byte("---\ntitle: [Bar\n---\nB\n"), 0600)
+	assert.Nil(t, err)
+	_, err = NewMarkdownPrinter(test.DebugLogger(), fpath, opts)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+}
+
+func TestMarkdownPrinterFrontMatterFixture(t *testing.T) {
+	fpath := test.CopyFpaths(t, test.MarkdownFpath(t, "frontmatter.md"))[0]
+	opts := DefaultMarkdownPrinterOptions(conf.DefaultConfig())
+	opts.Files = []string{"frontmatter.md"}
+	p, err := NewMarkdownPrinter(test.DebugLogger(), filepath.Join(filepath.Dir(fpath), "index.html"), opts)
+	assert.Nil(t, err)
+	chrome := p.(chromePrinter)
+	assert.Equal(t, map[string]string{"Title": "Gutenberg", "Author": "Johannes Gutenberg"}, chrome.opts.Metadata)
+	b, err := ioutil.ReadFile(strings.TrimPrefix(chrome.url, "file://"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(b), "This paragraph has a front matter</h2>")
+	assert.NotContains(t, string(b), "author:")
+}
+
+func TestMarkdownPrinterRenderers(t *testing.T) {
+	// without index.html, the default
+	// document includes "renderers.md".
+	fpath := filepath.Join(filepath.Dir(test.CopyFpaths(t, test.MarkdownFpath(t, "renderers.md"))[0]), "index.html")
+	opts := DefaultMarkdownPrinterOptions(conf.DefaultConfig())
+	p, err := NewMarkdownPrinter(test.DebugLogger(), fpath, opts)
+	assert.Nil(t, err)
+	chrome := p.(chromePrinter)
+	assert.Equal(t, renderersReadyExpression, chrome.opts.WaitForExpression)
+	b, err := ioutil.ReadFile(strings.TrimPrefix(chrome.url, "file://"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(b), `<span class="math math-inline">A = w \times h</span>`)
+	assert.Contains(t, string(b), `<div class="mermaid">graph LR`)
+	assert.Contains(t, string(b), "katex.min.js")
+	assert.Contains(t, string(b), "mermaid.min.js")
+	err = os.Remove(strings.TrimPrefix(chrome.url, "file://"))
+	assert.Nil(t, err)
+	// without JavaScript.
+	opts.Chrome.DisableJavaScript = true
+	p, err = NewMarkdownPrinter(test.DebugLogger(), fpath, opts)
+	assert.Nil(t, err)
+	chrome = p.(chromePrinter)
+	assert.Equal(t, "", chrome.opts.WaitForExpression)
+	err = os.Remove(strings.TrimPrefix(chrome.url, "file://"))
+	assert.Nil(t, err)
+}
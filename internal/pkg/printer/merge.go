@@ -22,6 +22,7 @@ type mergePrinter struct {
 // merge Printer behaviour.
 type MergePrinterOptions struct {
 	WaitTimeout float64
+	Stamp       StampOptions
 }
 
 // DefaultMergePrinterOptions returns the default
@@ -29,6 +30,7 @@ type MergePrinterOptions struct {
 func DefaultMergePrinterOptions(config conf.Config) MergePrinterOptions {
 	return MergePrinterOptions{
 		WaitTimeout: config.DefaultWaitTimeout(),
+		Stamp:       DefaultStampOptions(),
 	}
 }
 
@@ -62,7 +64,10 @@ func (p mergePrinter) Print(destination string) error {
 		var args []string
 		args = append(args, p.fpaths...)
 		args = append(args, "cat", "output", destination)
-		return xexec.Run(p.ctx, p.logger, "pdftk", args...)
+		if err := xexec.Run(p.ctx, p.logger, "pdftk", args...); err != nil {
+			return err
+		}
+		return stamp(p.ctx, p.logger, destination, p.opts.Stamp)
 	}
 	if err := resolver(); err != nil {
 		return xcontext.MustHandleError(
@@ -29,6 +29,17 @@ func TestMergePrinter(t *testing.T) {
 	assert.Nil(t, err)
 	err = os.RemoveAll(dest)
 	assert.Nil(t, err)
+	// options with a stamp.
+	opts = DefaultMergePrinterOptions(config)
+	opts.Stamp.Format = "ACME-{page:6}"
+	opts.Stamp.StartNumber = 42
+	opts.Stamp.Position = "bottom-right"
+	p = NewMergePrinter(logger, fpaths, opts)
+	dest = test.GenerateDestination()
+	err = p.Print(dest)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
 	// should not be OK as context.Context
 	// should timeout.
 	opts = DefaultMergePrinterOptions(config)
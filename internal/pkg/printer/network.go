@@ -0,0 +1,169 @@
+package printer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/mafredri/cdp/protocol/network"
+)
+
+const blockedByClientErrorText string = "net::ERR_BLOCKED_BY_CLIENT"
+
+// networkRecorder stores the outcome of the
+// network requests of a page.
+type networkRecorder struct {
+	mu                  sync.Mutex
+	mainLoaderID        network.LoaderID
+	urls                map[network.RequestID]string
+	documentLoaderIDs   map[network.RequestID]network.LoaderID
+	documentStatusCodes map[network.LoaderID]int
+	failedResources     []string
+	// mainDocument is closed once the response
+	// (or the loading failure) of the main
+	// document has been recorded.
+	mainDocument       chan struct{}
+	mainDocumentClosed bool
+}
+
+func newNetworkRecorder() *networkRecorder {
+	return &networkRecorder{
+		urls:                make(map[network.RequestID]string),
+		documentLoaderIDs:   make(map[network.RequestID]network.LoaderID),
+		documentStatusCodes: make(map[network.LoaderID]int),
+		mainDocument:        make(chan struct{}),
+	}
+}
+
+func (n *networkRecorder) setMainLoaderID(loaderID network.LoaderID) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.mainLoaderID = loaderID
+	// the response of the main document may
+	// have been recorded before we knew its
+	// loader.
+	n.notifyMainDocument()
+}
+
+func (n *networkRecorder) recordRequest(ev *network.RequestWillBeSentReply) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.urls[ev.RequestID] = ev.Request.URL
+	if ev.Type == network.ResourceTypeDocument {
+		n.documentLoaderIDs[ev.RequestID] = ev.LoaderID
+	}
+}
+
+func (n *networkRecorder) recordResponse(ev *network.ResponseReceivedReply) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if ev.Type == network.ResourceTypeDocument {
+		n.documentStatusCodes[ev.LoaderID] = ev.Response.Status
+		n.notifyMainDocument()
+		return
+	}
+	if ev.Response.Status >= 400 {
+		n.failedResources = append(
+			n.failedResources,
+			fmt.Sprintf("'%s' (%d %s)", ev.Response.URL, ev.Response.Status, ev.Response.StatusText),
+		)
+	}
+}
+
+func (n *networkRecorder) recordLoadingFailed(ev *network.LoadingFailedReply) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if ev.Type == network.ResourceTypeDocument {
+		// a document which failed to load
+		// does not have a status code.
+		loaderID, ok := n.documentLoaderIDs[ev.RequestID]
+		if !ok {
+			return
+		}
+		if _, ok := n.documentStatusCodes[loaderID]; !ok {
+			n.documentStatusCodes[loaderID] = 0
+		}
+		n.notifyMainDocument()
+		return
+	}
+	// requests we aborted on purpose are
+	// not considered as failed resources.
+	if ev.ErrorText == blockedByClientErrorText {
+		return
+	}
+	n.failedResources = append(
+		n.failedResources,
+		fmt.Sprintf("'%s' (%s)", n.urls[ev.RequestID], ev.ErrorText),
+	)
+}
+
+// notifyMainDocument closes the mainDocument
+// channel if the main document has been recorded.
+// The caller must hold the lock.
+func (n *networkRecorder) notifyMainDocument() {
+	if n.mainDocumentClosed || n.mainLoaderID == "" {
+		return
+	}
+	if _, ok := n.documentStatusCodes[n.mainLoaderID]; !ok {
+		return
+	}
+	close(n.mainDocument)
+	n.mainDocumentClosed = true
+}
+
+/*
+waitMainDocument waits for the response (or the
+loading failure) of the main document to be
+recorded, as the network events are recorded
+asynchronously.
+
+It returns immediately if the main document
+is unknown (e.g. a same-document navigation).
+*/
+func (n *networkRecorder) waitMainDocument(ctx context.Context) error {
+	n.mu.Lock()
+	mainLoaderID := n.mainLoaderID
+	n.mu.Unlock()
+	if mainLoaderID == "" {
+		return nil
+	}
+	select {
+	case <-n.mainDocument:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// mainDocumentStatusCode returns the HTTP status
+// code of the main document, or 0 if unknown.
+func (n *networkRecorder) mainDocumentStatusCode() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.documentStatusCodes[n.mainLoaderID]
+}
+
+func (n *networkRecorder) allFailedResources() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]string(nil), n.failedResources...)
+}
+
+/*
+matchHTTPStatusCode returns true if given status
+code is one of given codes.
+
+A code ending with 99 (e.g. 499) matches
+its whole class (e.g. 400 to 499).
+*/
+func matchHTTPStatusCode(statusCode int, codes []int64) bool {
+	for _, code := range codes {
+		if int64(statusCode) == code {
+			return true
+		}
+		if code%100 == 99 && int64(statusCode)/100 == code/100 {
+			return true
+		}
+	}
+	return false
+}
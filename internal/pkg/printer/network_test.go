@@ -0,0 +1,168 @@
+package printer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mafredri/cdp/protocol/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+)
+
+func TestNetworkRecorder(t *testing.T) {
+	n := newNetworkRecorder()
+	n.setMainLoaderID("foo")
+	// main document status code.
+	assert.Equal(t, 0, n.mainDocumentStatusCode())
+	n.recordResponse(&network.ResponseReceivedReply{
+		LoaderID: "foo",
+		Type:     network.ResourceTypeDocument,
+		Response: network.Response{URL: "https://google.com", Status: 404},
+	})
+	assert.Equal(t, 404, n.mainDocumentStatusCode())
+	// iframes should not be taken into account.
+	n.recordResponse(&network.ResponseReceivedReply{
+		LoaderID: "bar",
+		Type:     network.ResourceTypeDocument,
+		Response: network.Response{URL: "https://google.com/iframe", Status: 500},
+	})
+	assert.Equal(t, 404, n.mainDocumentStatusCode())
+	assert.Empty(t, n.allFailedResources())
+	// failed resources.
+	n.recordResponse(&network.ResponseReceivedReply{
+		Type:     network.ResourceTypeImage,
+		Response: network.Response{URL: "https://google.com/img.png", Status: 200},
+	})
+	assert.Empty(t, n.allFailedResources())
+	n.recordResponse(&network.ResponseReceivedReply{
+		Type:     network.ResourceTypeStylesheet,
+		Response: network.Response{URL: "https://google.com/style.css", Status: 404, StatusText: "Not Found"},
+	})
+	n.recordRequest(&network.RequestWillBeSentReply{
+		RequestID: "baz",
+		Request:   network.Request{URL: "https://google.com/font.woff"},
+	})
+	n.recordLoadingFailed(&network.LoadingFailedReply{
+		RequestID: "baz",
+		Type:      network.ResourceTypeFont,
+		ErrorText: "net::ERR_NAME_NOT_RESOLVED",
+	})
+	expected := []string{
+		"'https://google.com/style.css' (404 Not Found)",
+		"'https://google.com/font.woff' (net::ERR_NAME_NOT_RESOLVED)",
+	}
+	assert.Equal(t, expected, n.allFailedResources())
+}
+
+func TestNetworkRecorderWaitMainDocument(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// should return immediately as the
+	// main document is unknown.
+	n := newNetworkRecorder()
+	assert.Nil(t, n.waitMainDocument(ctx))
+	// should wait for the response of
+	// the main document.
+	n = newNetworkRecorder()
+	n.setMainLoaderID("foo")
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		n.recordResponse(&network.ResponseReceivedReply{
+			LoaderID: "foo",
+			Type:     network.ResourceTypeDocument,
+			Response: network.Response{URL: "https://google.com", Status: 404},
+		})
+	}()
+	assert.Nil(t, n.waitMainDocument(ctx))
+	assert.Equal(t, 404, n.mainDocumentStatusCode())
+	// should not wait if the response has been
+	// recorded before the main loader.
+	n = newNetworkRecorder()
+	n.recordResponse(&network.ResponseReceivedReply{
+		LoaderID: "foo",
+		Type:     network.ResourceTypeDocument,
+		Response: network.Response{URL: "https://google.com", Status: 500},
+	})
+	n.setMainLoaderID("foo")
+	assert.Nil(t, n.waitMainDocument(ctx))
+	assert.Equal(t, 500, n.mainDocumentStatusCode())
+	// should not wait forever if the main
+	// document failed to load.
+	n = newNetworkRecorder()
+	n.setMainLoaderID("foo")
+	n.recordRequest(&network.RequestWillBeSentReply{
+		RequestID: "foo",
+		LoaderID:  "foo",
+		Type:      network.ResourceTypeDocument,
+		Request:   network.Request{URL: "https://foo.invalid"},
+	})
+	n.recordLoadingFailed(&network.LoadingFailedReply{
+		RequestID: "foo",
+		Type:      network.ResourceTypeDocument,
+		ErrorText: "net::ERR_NAME_NOT_RESOLVED",
+	})
+	assert.Nil(t, n.waitMainDocument(ctx))
+	assert.Equal(t, 0, n.mainDocumentStatusCode())
+	assert.Empty(t, n.allFailedResources())
+	// should stop waiting with the context.
+	n = newNetworkRecorder()
+	n.setMainLoaderID("foo")
+	n.recordResponse(&network.ResponseReceivedReply{
+		LoaderID: "bar",
+		Type:     network.ResourceTypeDocument,
+		Response: network.Response{URL: "https://google.com/iframe", Status: 200},
+	})
+	shortCtx, shortCancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer shortCancel()
+	assert.Equal(t, context.DeadlineExceeded, n.waitMainDocument(shortCtx))
+}
+
+func TestChromePrinterCheckNetwork(t *testing.T) {
+	// should fail reliably even if the response
+	// of the main document is recorded late.
+	for _, statusCode := range []int{404, 500, 503} {
+		for i := 0; i < 20; i++ {
+			n := newNetworkRecorder()
+			n.setMainLoaderID("foo")
+			p := chromePrinter{
+				url:      "https://google.com",
+				opts:     ChromePrinterOptions{FailOnHTTPStatusCodes: []int64{499, 599}},
+				requests: n,
+			}
+			go n.recordResponse(&network.ResponseReceivedReply{
+				LoaderID: "foo",
+				Type:     network.ResourceTypeDocument,
+				Response: network.Response{URL: "https://google.com", Status: statusCode},
+			})
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			err := p.checkNetwork(ctx)
+			cancel()
+			assert.NotNil(t, err)
+			assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+		}
+	}
+	// should be OK with a successful main document.
+	n := newNetworkRecorder()
+	n.setMainLoaderID("foo")
+	p := chromePrinter{
+		url:      "https://google.com",
+		opts:     ChromePrinterOptions{FailOnHTTPStatusCodes: []int64{499, 599}},
+		requests: n,
+	}
+	go n.recordResponse(&network.ResponseReceivedReply{
+		LoaderID: "foo",
+		Type:     network.ResourceTypeDocument,
+		Response: network.Response{URL: "https://google.com", Status: 200},
+	})
+	assert.Nil(t, p.checkNetwork(context.Background()))
+}
+
+func TestMatchHTTPStatusCode(t *testing.T) {
+	assert.Equal(t, false, matchHTTPStatusCode(404, nil))
+	assert.Equal(t, true, matchHTTPStatusCode(404, []int64{404}))
+	assert.Equal(t, false, matchHTTPStatusCode(403, []int64{404}))
+	assert.Equal(t, true, matchHTTPStatusCode(403, []int64{499}))
+	assert.Equal(t, true, matchHTTPStatusCode(503, []int64{404, 599}))
+	assert.Equal(t, false, matchHTTPStatusCode(200, []int64{499, 599}))
+}
@@ -1,8 +1,11 @@
 package printer
 
 import (
+	"archive/zip"
 	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"sort"
@@ -26,18 +29,30 @@ type officePrinter struct {
 // OfficePrinterOptions helps customizing the
 // Office Printer behaviour.
 type OfficePrinterOptions struct {
-	WaitTimeout float64
-	Landscape   bool
-	PageRanges  string
+	WaitTimeout      float64
+	Landscape        bool
+	PageRanges       string
+	CSVDelimiter     string
+	CSVEncoding      string
+	OutputFormat     string
+	DocumentPassword string
+	PDFExport        PDFExportOptions
+	Stamp            StampOptions
 }
 
 // DefaultOfficePrinterOptions returns the default
 // Office Printer options.
 func DefaultOfficePrinterOptions(config conf.Config) OfficePrinterOptions {
 	return OfficePrinterOptions{
-		WaitTimeout: config.DefaultWaitTimeout(),
-		Landscape:   false,
-		PageRanges:  "",
+		WaitTimeout:      config.DefaultWaitTimeout(),
+		Landscape:        false,
+		PageRanges:       "",
+		CSVDelimiter:     ",",
+		CSVEncoding:      "UTF-8",
+		OutputFormat:     "pdf",
+		DocumentPassword: "",
+		PDFExport:        DefaultPDFExportOptions(),
+		Stamp:            DefaultStampOptions(),
 	}
 }
 
@@ -51,21 +66,58 @@ func NewOfficePrinter(logger xlog.Logger, fpaths []string, opts OfficePrinterOpt
 	}
 }
 
+/*
+ValidateOfficeOutputOptions returns an error if
+the page numbers or the PDF export options are
+given while the output format is not PDF.
+
+Otherwise they would be silently ignored.
+*/
+func ValidateOfficeOutputOptions(opts OfficePrinterOptions) error {
+	const op string = "printer.ValidateOfficeOutputOptions"
+	if opts.OutputFormat == "" || opts.OutputFormat == "pdf" {
+		return nil
+	}
+	if opts.Stamp.Format != "" {
+		return xerror.Invalid(
+			op,
+			fmt.Sprintf("page numbers cannot be stamped on the '%s' output format", opts.OutputFormat),
+			nil,
+		)
+	}
+	if len(opts.PDFExport.filterData()) > 0 {
+		return xerror.Invalid(
+			op,
+			fmt.Sprintf("PDF export options cannot be applied to the '%s' output format", opts.OutputFormat),
+			nil,
+		)
+	}
+	return nil
+}
+
 func (p officePrinter) Print(destination string) error {
 	const op string = "printer.officePrinter.Print"
-	logOptions(p.logger, p.opts)
+	// do not log the password.
+	loggedOpts := p.opts
+	if loggedOpts.DocumentPassword != "" {
+		loggedOpts.DocumentPassword = "***"
+	}
+	logOptions(p.logger, loggedOpts)
 	ctx, cancel := xcontext.WithTimeout(p.logger, p.opts.WaitTimeout)
 	defer cancel()
 	resolver := func() error {
 		// see https://github.com/thecodingmachine/gotenberg/issues/139.
 		sort.Strings(p.fpaths)
+		if p.opts.OutputFormat != "" && p.opts.OutputFormat != "pdf" {
+			return p.export(ctx, destination)
+		}
 		fpaths := make([]string, len(p.fpaths))
 		dirPath := filepath.Dir(destination)
 		for i, fpath := range p.fpaths {
 			baseFilename := xrand.Get()
 			tmpDest := fmt.Sprintf("%s/%d%s.pdf", dirPath, i, baseFilename)
 			p.logger.DebugOpf(op, "converting '%s' to PDF...", fpath)
-			if err := p.unoconv(ctx, fpath, tmpDest); err != nil {
+			if err := p.unoconv(ctx, fpath, tmpDest, "pdf"); err != nil {
 				return err
 			}
 			p.logger.DebugOpf(op, "'%s.pdf' created", baseFilename)
@@ -73,14 +125,21 @@ func (p officePrinter) Print(destination string) error {
 		}
 		if len(fpaths) == 1 {
 			p.logger.DebugOp(op, "only one PDF created, nothing to merge")
-			return os.Rename(fpaths[0], destination)
-		}
-		m := mergePrinter{
-			logger: p.logger,
-			ctx:    ctx,
-			fpaths: fpaths,
+			if err := os.Rename(fpaths[0], destination); err != nil {
+				return err
+			}
+		} else {
+			m := mergePrinter{
+				logger: p.logger,
+				ctx:    ctx,
+				fpaths: fpaths,
+			}
+			if err := m.Print(destination); err != nil {
+				return err
+			}
 		}
-		return m.Print(destination)
+		// stamps all the pages of the resulting PDF.
+		return stamp(ctx, p.logger, destination, p.opts.Stamp)
 	}
 	if err := resolver(); err != nil {
 		return xcontext.MustHandleError(
@@ -91,7 +150,162 @@ func (p officePrinter) Print(destination string) error {
 	return nil
 }
 
-func (p officePrinter) unoconv(ctx context.Context, fpath, destination string) error {
+/*
+Output returns the file extension and the
+content type of the result: the Office documents
+converted to other formats than PDF are archived
+in a ZIP file, unless there is only one document
+converted to a single file.
+*/
+func (p officePrinter) Output() (string, string) {
+	format, ok := lookupOfficeOutputFormat(p.opts.OutputFormat)
+	if !ok || format.format == "pdf" {
+		return ".pdf", "application/pdf"
+	}
+	if format.format == "png" || len(p.fpaths) > 1 {
+		return ".zip", "application/zip"
+	}
+	return fmt.Sprintf(".%s", format.format), format.contentType
+}
+
+/*
+export converts the Office documents to the
+output format, which is not PDF.
+
+Each resulting file is named after its document
+(e.g. "document.odt" or "slides-1.png").
+*/
+func (p officePrinter) export(ctx context.Context, destination string) error {
+	const op string = "printer.officePrinter.export"
+	resolver := func() error {
+		dirPath, err := ioutil.TempDir(filepath.Dir(destination), "export")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(dirPath) // nolint: errcheck
+		var (
+			fpaths []string
+			names  = make(map[string]bool)
+		)
+		for i, fpath := range p.fpaths {
+			name := strings.TrimSuffix(filepath.Base(fpath), filepath.Ext(fpath))
+			// documents may share a name
+			// (e.g. "document.doc" and "document.docx").
+			if names[name] {
+				name = fmt.Sprintf("%s-%d", name, i+1)
+			}
+			names[name] = true
+			if p.opts.OutputFormat == "png" {
+				images, err := p.images(ctx, fpath, filepath.Join(dirPath, fmt.Sprintf("%d", i)), name)
+				if err != nil {
+					return err
+				}
+				fpaths = append(fpaths, images...)
+				continue
+			}
+			tmpDest := filepath.Join(dirPath, fmt.Sprintf("%s.%s", name, p.opts.OutputFormat))
+			p.logger.DebugOpf(op, "converting '%s' to '%s'...", fpath, p.opts.OutputFormat)
+			if err := p.unoconv(ctx, fpath, tmpDest, p.opts.OutputFormat); err != nil {
+				return err
+			}
+			fpaths = append(fpaths, tmpDest)
+		}
+		if ext, _ := p.Output(); ext != ".zip" {
+			p.logger.DebugOp(op, "only one file created, nothing to archive")
+			return os.Rename(fpaths[0], destination)
+		}
+		p.logger.DebugOpf(op, "archiving '%d' files...", len(fpaths))
+		return archive(fpaths, destination)
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+/*
+images converts given Office document to PDF,
+then each page of this PDF to a PNG image in
+given directory.
+
+It returns the paths of the images, in order.
+*/
+func (p officePrinter) images(ctx context.Context, fpath, dirPath, name string) ([]string, error) {
+	const op string = "printer.officePrinter.images"
+	resolver := func() ([]string, error) {
+		if err := os.Mkdir(dirPath, 0700); err != nil {
+			return nil, err
+		}
+		tmpDest := filepath.Join(dirPath, fmt.Sprintf("%s.pdf", xrand.Get()))
+		p.logger.DebugOpf(op, "converting '%s' to PDF...", fpath)
+		if err := p.unoconv(ctx, fpath, tmpDest, "pdf"); err != nil {
+			return nil, err
+		}
+		p.logger.DebugOpf(op, "converting the pages of '%s' to PNG...", fpath)
+		prefix := filepath.Join(dirPath, name)
+		if err := xexec.Run(ctx, p.logger, "pdftoppm", "-png", "-r", "150", tmpDest, prefix); err != nil {
+			return nil, err
+		}
+		files, err := ioutil.ReadDir(dirPath)
+		if err != nil {
+			return nil, err
+		}
+		// pdftoppm pads the page numbers, so
+		// that the alphabetical order works.
+		var images []string
+		for _, file := range files {
+			if filepath.Ext(file.Name()) == ".png" {
+				images = append(images, filepath.Join(dirPath, file.Name()))
+			}
+		}
+		sort.Strings(images)
+		return images, nil
+	}
+	result, err := resolver()
+	if err != nil {
+		return nil, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+// archive writes given files in a
+// ZIP archive, under their base name.
+func archive(fpaths []string, destination string) error {
+	const op string = "printer.archive"
+	resolver := func() error {
+		f, err := os.Create(destination)
+		if err != nil {
+			return err
+		}
+		defer f.Close() // nolint: errcheck
+		w := zip.NewWriter(f)
+		for _, fpath := range fpaths {
+			entry, err := w.Create(filepath.Base(fpath))
+			if err != nil {
+				return err
+			}
+			in, err := os.Open(fpath)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(entry, in)
+			in.Close() // nolint: errcheck
+			if err != nil {
+				return err
+			}
+		}
+		if err := w.Close(); err != nil {
+			return err
+		}
+		return f.Close()
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+func (p officePrinter) unoconv(ctx context.Context, fpath, destination, format string) error {
 	const op string = "printer.unoconv"
 	resolver := func() error {
 		dirName := xrand.Get()
@@ -105,14 +319,31 @@ func (p officePrinter) unoconv(ctx context.Context, fpath, destination string) e
 			"--port",
 			fmt.Sprintf("%d", port),
 			"--format",
-			"pdf",
+			format,
 		}
 		if p.opts.Landscape {
 			args = append(args, "--printer", "PaperOrientation=landscape")
 		}
-		if p.opts.PageRanges != "" {
+		if format == "pdf" {
+			args = append(args, p.opts.PDFExport.unoconvArgs()...)
+		}
+		if p.opts.PageRanges != "" && format == "pdf" {
 			args = append(args, "--export", fmt.Sprintf("PageRange=%s", p.opts.PageRanges))
 		}
+		csvOptions := csvFilterOptions(p.opts.CSVDelimiter, p.opts.CSVEncoding)
+		if strings.ToLower(filepath.Ext(fpath)) == ".csv" {
+			args = append(args, "--import", csvOptions)
+		}
+		switch format {
+		case "csv":
+			args = append(args, "--export", csvOptions)
+		case "html":
+			// the images are embedded in the HTML.
+			args = append(args, "--export", "FilterOptions=EmbedImages")
+		}
+		if p.opts.DocumentPassword != "" {
+			args = append(args, "--password", p.opts.DocumentPassword)
+		}
 		args = append(args, "--output", destination, fpath)
 		err = xexec.Run(ctx, p.logger, "unoconv", args...)
 		// always remove user profile folders created by LibreOffice.
@@ -120,13 +351,25 @@ func (p officePrinter) unoconv(ctx context.Context, fpath, destination string) e
 		go cleanupUserProfile(p.logger, dirName)
 		if err != nil {
 			// find a way to check it in the handlers?
-			if p.opts.PageRanges != "" && strings.Contains(err.Error(), "exit status 5") {
+			if p.opts.PageRanges != "" && format == "pdf" && strings.Contains(err.Error(), "exit status 5") {
 				return xerror.Invalid(
 					op,
 					fmt.Sprintf("'%s' is not a valid LibreOffice page ranges", p.opts.PageRanges),
 					err,
 				)
 			}
+			// LibreOffice fails to open a password
+			// protected document with a wrong password.
+			if p.opts.DocumentPassword != "" && ctx.Err() == nil {
+				protected, protectedErr := isPasswordProtected(fpath)
+				if protectedErr == nil && protected {
+					return xerror.Invalid(
+						op,
+						fmt.Sprintf("'%s' cannot be opened with the given password", filepath.Base(fpath)),
+						err,
+					)
+				}
+			}
 			return err
 		}
 		return nil
@@ -149,4 +392,5 @@ func cleanupUserProfile(logger xlog.Logger, dirName string) {
 // Compile-time checks to ensure type implements desired interfaces.
 var (
 	_ = Printer(new(officePrinter))
+	_ = OutputReporter(new(officePrinter))
 )
@@ -1,6 +1,7 @@
 package printer
 
 import (
+	"archive/zip"
 	"os"
 	"testing"
 
@@ -55,6 +56,51 @@ func TestOfficePrinter(t *testing.T) {
 	assert.Nil(t, err)
 	err = os.RemoveAll(dest)
 	assert.Nil(t, err)
+	// options with a stamp.
+	opts = DefaultOfficePrinterOptions(config)
+	opts.Stamp.Format = "Page {page} of {total}"
+	opts.Stamp.Font = "Times-Roman"
+	p = NewOfficePrinter(logger, fpaths, opts)
+	dest = test.GenerateDestination()
+	err = p.Print(dest)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// options with PDF export options.
+	quality, maxImageResolution := int64(50), int64(150)
+	enabled, disabled := true, false
+	opts = DefaultOfficePrinterOptions(config)
+	opts.PDFExport.Quality = &quality
+	opts.PDFExport.ReduceImageResolution = &enabled
+	opts.PDFExport.MaxImageResolution = &maxImageResolution
+	opts.PDFExport.ExportBookmarks = &disabled
+	opts.PDFExport.SkipEmptyPages = &enabled
+	p = NewOfficePrinter(logger, fpaths, opts)
+	dest = test.GenerateDestination()
+	err = p.Print(dest)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// options with a document password.
+	opts = DefaultOfficePrinterOptions(config)
+	opts.DocumentPassword = "foo"
+	p = NewOfficePrinter(logger, []string{test.ProtectedOfficeFpath(t)}, opts)
+	dest = test.GenerateDestination()
+	err = p.Print(dest)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// should not be OK as options have
+	// a wrong document password.
+	opts = DefaultOfficePrinterOptions(config)
+	opts.DocumentPassword = "bar"
+	p = NewOfficePrinter(logger, []string{test.ProtectedOfficeFpath(t)}, opts)
+	dest = test.GenerateDestination()
+	err = p.Print(dest)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
 	// should not be OK as options have
 	// a wrong page ranges.
 	opts = DefaultOfficePrinterOptions(config)
@@ -78,3 +124,114 @@ func TestOfficePrinter(t *testing.T) {
 	err = os.RemoveAll(dest)
 	assert.Nil(t, err)
 }
+
+func TestOfficePrinterFormats(t *testing.T) {
+	logger := test.DebugLogger()
+	opts := DefaultOfficePrinterOptions(conf.DefaultConfig())
+	// the CSV file uses semicolons.
+	opts.CSVDelimiter = ";"
+	for _, fpath := range test.OfficeFormatsFpaths(t) {
+		p := NewOfficePrinter(logger, []string{fpath}, opts)
+		dest := test.GenerateDestination()
+		err := p.Print(dest)
+		assert.Nil(t, err, fpath)
+		err = os.RemoveAll(dest)
+		assert.Nil(t, err)
+	}
+}
+
+func TestOfficePrinterOutput(t *testing.T) {
+	var (
+		logger xlog.Logger          = test.DebugLogger()
+		opts   OfficePrinterOptions = DefaultOfficePrinterOptions(conf.DefaultConfig())
+		ext    string
+		ct     string
+	)
+	// default options.
+	ext, ct = NewOfficePrinter(logger, []string{"a.docx"}, opts).(OutputReporter).Output()
+	assert.Equal(t, ".pdf", ext)
+	assert.Equal(t, "application/pdf", ct)
+	// one document to "docx".
+	opts.OutputFormat = "docx"
+	ext, ct = NewOfficePrinter(logger, []string{"a.odt"}, opts).(OutputReporter).Output()
+	assert.Equal(t, ".docx", ext)
+	assert.Equal(t, "application/vnd.openxmlformats-officedocument.wordprocessingml.document", ct)
+	// many documents to "docx".
+	ext, ct = NewOfficePrinter(logger, []string{"a.odt", "b.rtf"}, opts).(OutputReporter).Output()
+	assert.Equal(t, ".zip", ext)
+	assert.Equal(t, "application/zip", ct)
+	// one document to "png".
+	opts.OutputFormat = "png"
+	ext, ct = NewOfficePrinter(logger, []string{"a.pptx"}, opts).(OutputReporter).Output()
+	assert.Equal(t, ".zip", ext)
+	assert.Equal(t, "application/zip", ct)
+}
+
+func TestOfficePrinterOutputFormats(t *testing.T) {
+	logger := test.DebugLogger()
+	opts := DefaultOfficePrinterOptions(conf.DefaultConfig())
+	for format, fpaths := range map[string][]string{
+		"docx": {test.OfficeFpath(t, "document.rtf")},
+		"html": test.OfficeFpaths(t),
+		"csv":  {test.OfficeFpath(t, "document.fods")},
+		"png":  {test.OfficeFpath(t, "document.fodp")},
+	} {
+		opts.OutputFormat = format
+		p := NewOfficePrinter(logger, fpaths, opts)
+		dest := test.GenerateDestination()
+		err := p.Print(dest)
+		assert.Nil(t, err, format)
+		err = os.RemoveAll(dest)
+		assert.Nil(t, err)
+	}
+}
+
+func TestValidateOfficeOutputOptions(t *testing.T) {
+	opts := DefaultOfficePrinterOptions(conf.DefaultConfig())
+	quality := int64(50)
+	// PDF output format.
+	opts.Stamp.Format = "Page {page} of {total}"
+	opts.PDFExport.Quality = &quality
+	assert.Nil(t, ValidateOfficeOutputOptions(opts))
+	// another output format without
+	// PDF options.
+	opts = DefaultOfficePrinterOptions(conf.DefaultConfig())
+	opts.OutputFormat = "docx"
+	assert.Nil(t, ValidateOfficeOutputOptions(opts))
+	// should not be OK as page numbers
+	// cannot be stamped on a "docx" file.
+	opts.Stamp.Format = "Page {page} of {total}"
+	err := ValidateOfficeOutputOptions(opts)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	// should not be OK as PDF export options
+	// cannot be applied to a "png" file.
+	opts = DefaultOfficePrinterOptions(conf.DefaultConfig())
+	opts.OutputFormat = "png"
+	opts.PDFExport.Quality = &quality
+	err = ValidateOfficeOutputOptions(opts)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+}
+
+func TestArchive(t *testing.T) {
+	fpaths := test.OfficeFpaths(t)[:2]
+	dest := test.GenerateDestination()
+	err := archive(fpaths, dest)
+	assert.Nil(t, err)
+	r, err := zip.OpenReader(dest)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(r.File))
+	assert.Equal(t, "document.docx", r.File[0].Name)
+	assert.Equal(t, "document.rtf", r.File[1].Name)
+	err = r.Close()
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// should not be OK as
+	// a file does not exist.
+	err = archive([]string{"/foo/bar.docx"}, dest)
+	test.AssertError(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,121 @@
+package printer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mafredri/cdp"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+)
+
+// heading is a h1-h6 element of a page.
+type heading struct {
+	Level int     `json:"level"`
+	Title string  `json:"title"`
+	Top   float64 `json:"top"`
+}
+
+// headingsExpression returns the h1-h6 elements
+// of a page with their position (in CSS pixels).
+const headingsExpression string = `JSON.stringify(
+	Array.from(document.querySelectorAll('h1, h2, h3, h4, h5, h6')).map(h => ({
+		level: parseInt(h.tagName.substring(1), 10),
+		title: h.innerText,
+		top: h.getBoundingClientRect().top + window.scrollY
+	}))
+)`
+
+// cssPixelsPerInch is the number of
+// CSS pixels in an inch.
+const cssPixelsPerInch float64 = 96.0
+
+// hasOutline returns true if given PDF
+// data contains bookmarks.
+func hasOutline(data []byte) bool {
+	return bytes.Contains(data, []byte("/Outlines"))
+}
+
+/*
+addOutline builds the bookmarks of the PDF file
+at given path from the h1-h6 hierarchy of the page.
+
+It is a fallback for the Google Chrome versions
+which do not support the "generateDocumentOutline"
+parameter.
+*/
+func (p chromePrinter) addOutline(ctx context.Context, client *cdp.Client, fpath string) error {
+	const op string = "printer.chromePrinter.addOutline"
+	resolver := func() error {
+		if p.opts.PageRanges != "" {
+			p.logger.DebugOp(op, "skipping outline as page ranges have been provided...")
+			return nil
+		}
+		var headings []heading
+		if err := evaluateJSON(ctx, client, headingsExpression, &headings); err != nil {
+			return err
+		}
+		if len(headings) == 0 {
+			p.logger.DebugOp(op, "skipping outline as the page has no headings...")
+			return nil
+		}
+		p.logger.DebugOpf(op, "building outline from '%d' headings...", len(headings))
+		return updatePDFInfo(ctx, p.logger, fpath, bookmarksInfo(headings, p.pageHeight()))
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+/*
+pageHeight returns the printable height of
+a page in CSS pixels.
+
+It does not take into account the page size
+from the CSS, so that the page numbers of the
+bookmarks are a best guess.
+*/
+func (p chromePrinter) pageHeight() float64 {
+	paperHeight := p.opts.PaperHeight
+	if p.opts.Landscape {
+		paperHeight = p.opts.PaperWidth
+	}
+	return (paperHeight - p.opts.MarginTop - p.opts.MarginBottom) * cssPixelsPerInch / p.opts.Scale
+}
+
+/*
+bookmarksInfo returns a pdftk info file with
+a bookmark for each heading.
+
+The levels are normalized so that a bookmark
+is never more than one level deeper than
+its predecessor.
+*/
+func bookmarksInfo(headings []heading, pageHeight float64) string {
+	var info strings.Builder
+	previousLevel := 0
+	minLevel := 6
+	for _, h := range headings {
+		if h.Level < minLevel {
+			minLevel = h.Level
+		}
+	}
+	for _, h := range headings {
+		title := strings.Join(strings.Fields(h.Title), " ")
+		if title == "" {
+			continue
+		}
+		level := h.Level - minLevel + 1
+		if level > previousLevel+1 {
+			level = previousLevel + 1
+		}
+		previousLevel = level
+		info.WriteString("BookmarkBegin\n")
+		info.WriteString(fmt.Sprintf("BookmarkTitle: %s\n", title))
+		info.WriteString(fmt.Sprintf("BookmarkLevel: %d\n", level))
+		info.WriteString(fmt.Sprintf("BookmarkPageNumber: %d\n", pageNumber(h.Top, pageHeight)))
+	}
+	return info.String()
+}
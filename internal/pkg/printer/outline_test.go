@@ -0,0 +1,35 @@
+package printer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasOutline(t *testing.T) {
+	assert.Equal(t, true, hasOutline([]byte("<< /Type /Catalog /Outlines 3 0 R >>")))
+	assert.Equal(t, false, hasOutline([]byte("<< /Type /Catalog >>")))
+}
+
+func TestBookmarksInfo(t *testing.T) {
+	headings := []heading{
+		{Level: 2, Title: "Introduction", Top: 10},
+		{Level: 4, Title: "  Multi\n line  ", Top: 500},
+		{Level: 3, Title: "", Top: 600},
+		{Level: 2, Title: "Conclusion", Top: 2500},
+	}
+	expected := "BookmarkBegin\n" +
+		"BookmarkTitle: Introduction\n" +
+		"BookmarkLevel: 1\n" +
+		"BookmarkPageNumber: 1\n" +
+		"BookmarkBegin\n" +
+		"BookmarkTitle: Multi line\n" +
+		"BookmarkLevel: 2\n" +
+		"BookmarkPageNumber: 1\n" +
+		"BookmarkBegin\n" +
+		"BookmarkTitle: Conclusion\n" +
+		"BookmarkLevel: 1\n" +
+		"BookmarkPageNumber: 3\n"
+	assert.Equal(t, expected, bookmarksInfo(headings, 1000))
+	assert.Equal(t, "", bookmarksInfo(nil, 1000))
+}
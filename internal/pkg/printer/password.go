@@ -0,0 +1,132 @@
+package printer
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+)
+
+// nolint: gochecknoglobals
+var (
+	// oleSignature is the signature of the OLE
+	// compound files, like the encrypted OOXML
+	// documents.
+	oleSignature = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+	// ooxmlExtensions are the extensions of the
+	// OOXML documents, which are ZIP archives
+	// unless encrypted.
+	ooxmlExtensions = []string{".docx", ".xlsx", ".pptx", ".vsdx"}
+	// openDocumentExtensions are the extensions
+	// of the OpenDocument files which may be
+	// encrypted.
+	openDocumentExtensions = []string{".odt", ".ods", ".odp", ".odg"}
+)
+
+/*
+isPasswordProtected returns true if given
+Office document requires a password.
+
+It detects the encrypted OOXML documents and
+OpenDocument files, but not the encrypted legacy
+documents (e.g. ".doc").
+*/
+func isPasswordProtected(fpath string) (bool, error) {
+	const op string = "printer.isPasswordProtected"
+	resolver := func() (bool, error) {
+		ext := strings.ToLower(filepath.Ext(fpath))
+		switch {
+		case contains(ooxmlExtensions, ext):
+			// an encrypted OOXML document is an OLE
+			// compound file instead of a ZIP archive.
+			f, err := os.Open(fpath)
+			if err != nil {
+				return false, err
+			}
+			defer f.Close() // nolint: errcheck
+			signature := make([]byte, len(oleSignature))
+			if _, err := io.ReadFull(f, signature); err != nil {
+				// too small for being encrypted.
+				return false, nil
+			}
+			return bytes.Equal(signature, oleSignature), nil
+		case contains(openDocumentExtensions, ext):
+			// the manifest of an encrypted OpenDocument
+			// file has the encryption data of its files.
+			r, err := zip.OpenReader(fpath)
+			if err != nil {
+				// let LibreOffice handle
+				// the invalid archives.
+				return false, nil
+			}
+			defer r.Close() // nolint: errcheck
+			for _, file := range r.File {
+				if file.Name != "META-INF/manifest.xml" {
+					continue
+				}
+				in, err := file.Open()
+				if err != nil {
+					return false, err
+				}
+				defer in.Close() // nolint: errcheck
+				manifest, err := ioutil.ReadAll(in)
+				if err != nil {
+					return false, err
+				}
+				return bytes.Contains(manifest, []byte("encryption-data")), nil
+			}
+			return false, nil
+		default:
+			return false, nil
+		}
+	}
+	result, err := resolver()
+	if err != nil {
+		return result, xerror.New(op, err)
+	}
+	return result, nil
+}
+
+/*
+ValidateOfficePassword returns an error if
+one of the given files requires a password
+while none has been given.
+
+Otherwise LibreOffice waits for the password
+until the conversion times out.
+*/
+func ValidateOfficePassword(fpaths []string, password string) error {
+	const op string = "printer.ValidateOfficePassword"
+	if password != "" {
+		return nil
+	}
+	for _, fpath := range fpaths {
+		protected, err := isPasswordProtected(fpath)
+		if err != nil {
+			return xerror.New(op, err)
+		}
+		if protected {
+			return xerror.Invalid(
+				op,
+				fmt.Sprintf("'%s' requires a password", filepath.Base(fpath)),
+				nil,
+			)
+		}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package printer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/test"
+)
+
+func TestIsPasswordProtected(t *testing.T) {
+	// an encrypted OOXML document
+	// is an OLE compound file.
+	dirPath, err := ioutil.TempDir("", "password")
+	assert.Nil(t, err)
+	encrypted := filepath.Join(dirPath, "encrypted.docx")
+	err = ioutil.WriteFile(encrypted, append(oleSignature, 0, 0, 0, 0), 0600)
+	assert.Nil(t, err)
+	protected, err := isPasswordProtected(encrypted)
+	assert.Nil(t, err)
+	assert.True(t, protected)
+	// an encrypted OpenDocument file.
+	protected, err = isPasswordProtected(test.ProtectedOfficeFpath(t))
+	assert.Nil(t, err)
+	assert.True(t, protected)
+	// documents without password.
+	for _, fpath := range append(test.OfficeFpaths(t), test.OfficeFormatsFpaths(t)...) {
+		protected, err = isPasswordProtected(fpath)
+		assert.Nil(t, err)
+		assert.False(t, protected, fpath)
+	}
+	// should not be OK as the
+	// file does not exist.
+	_, err = isPasswordProtected(filepath.Join(dirPath, "foo.docx"))
+	test.AssertError(t, err)
+	err = os.RemoveAll(dirPath)
+	assert.Nil(t, err)
+}
+
+func TestValidateOfficePassword(t *testing.T) {
+	fpaths := append(test.OfficeFpaths(t), test.ProtectedOfficeFpath(t))
+	// documents without password.
+	err := ValidateOfficePassword(test.OfficeFpaths(t), "")
+	assert.Nil(t, err)
+	// a password has been given.
+	err = ValidateOfficePassword(fpaths, "foo")
+	assert.Nil(t, err)
+	// should not be OK as a
+	// password is required.
+	err = ValidateOfficePassword(fpaths, "")
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+}
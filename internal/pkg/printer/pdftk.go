@@ -0,0 +1,83 @@
+package printer
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xexec"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xrand"
+)
+
+/*
+updatePDFInfo updates the metadata and bookmarks
+of the PDF file at given path, thanks to a pdftk
+info file (UTF-8 encoded).
+
+See https://www.pdflabs.com/docs/pdftk-man-page/#dest-op-update-info.
+*/
+func updatePDFInfo(ctx context.Context, logger xlog.Logger, fpath, info string) error {
+	const op string = "printer.updatePDFInfo"
+	resolver := func() error {
+		dirPath := filepath.Dir(fpath)
+		infoFpath := filepath.Join(dirPath, fmt.Sprintf("%s.txt", xrand.Get()))
+		if err := ioutil.WriteFile(infoFpath, []byte(info), 0600); err != nil {
+			return err
+		}
+		defer os.Remove(infoFpath) // nolint: errcheck
+		return runPdftkInPlace(ctx, logger, fpath, "update_info_utf8", infoFpath)
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+/*
+metadataInfo returns a pdftk info file
+with given metadata (e.g. Title, Author).
+*/
+func metadataInfo(metadata map[string]string) string {
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var info strings.Builder
+	for _, key := range keys {
+		info.WriteString("InfoBegin\n")
+		info.WriteString(fmt.Sprintf("InfoKey: %s\n", key))
+		info.WriteString(fmt.Sprintf("InfoValue: %s\n", strings.Join(strings.Fields(metadata[key]), " ")))
+	}
+	return info.String()
+}
+
+/*
+runPdftkInPlace runs a pdftk operation on the PDF
+file at given path and replaces this file with
+the result.
+*/
+func runPdftkInPlace(ctx context.Context, logger xlog.Logger, fpath string, operation ...string) error {
+	const op string = "printer.runPdftkInPlace"
+	resolver := func() error {
+		tmpFpath := filepath.Join(filepath.Dir(fpath), fmt.Sprintf("%s.pdf", xrand.Get()))
+		var args []string
+		args = append(args, fpath)
+		args = append(args, operation...)
+		args = append(args, "output", tmpFpath)
+		if err := xexec.Run(ctx, logger, "pdftk", args...); err != nil {
+			return err
+		}
+		return os.Rename(tmpFpath, fpath)
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
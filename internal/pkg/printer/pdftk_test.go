@@ -0,0 +1,22 @@
+package printer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadataInfo(t *testing.T) {
+	metadata := map[string]string{
+		"Title":  "Foo\nBar",
+		"Author": "Jane",
+	}
+	expected := "InfoBegin\n" +
+		"InfoKey: Author\n" +
+		"InfoValue: Jane\n" +
+		"InfoBegin\n" +
+		"InfoKey: Title\n" +
+		"InfoValue: Foo Bar\n"
+	assert.Equal(t, expected, metadataInfo(metadata))
+	assert.Equal(t, "", metadataInfo(nil))
+}
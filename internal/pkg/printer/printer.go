@@ -10,6 +10,15 @@ type Printer interface {
 	Print(destination string) error
 }
 
+/*
+OutputReporter is a Printer which may create
+another file than a PDF: it returns the file
+extension and the content type of its result.
+*/
+type OutputReporter interface {
+	Output() (string, string)
+}
+
 func logOptions(logger xlog.Logger, opts interface{}) {
 	const op string = "printer.logOptions"
 	logger.DebugOpf(op, "options: %+v", opts)
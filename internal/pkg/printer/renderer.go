@@ -0,0 +1,253 @@
+package printer
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+/*
+renderersPath is the directory of the
+renderers bundled in the Docker image:
+KaTeX for the math and Mermaid for
+the diagrams.
+*/
+const renderersPath string = "/opt/gotenberg/renderers"
+
+// renderersReadyExpression is true once
+// the renderers are done.
+const renderersReadyExpression string = "window.gotenbergRenderersReady === true"
+
+// renderersScript renders the math and
+// the diagrams once the page is loaded.
+const renderersScript string = `<script>
+document.addEventListener('DOMContentLoaded', function () {
+	Promise.resolve().then(function () {
+		if (window.katex) {
+			document.querySelectorAll('.math').forEach(function (el) {
+				katex.render(el.textContent, el, {
+					displayMode: el.classList.contains('math-display'),
+					throwOnError: false
+				});
+			});
+		}
+		if (window.mermaid) {
+			mermaid.initialize({ startOnLoad: false });
+			return mermaid.init(undefined, document.querySelectorAll('.mermaid'));
+		}
+	}).catch(function (e) {
+		console.error(e);
+	}).then(function () {
+		window.gotenbergRenderersReady = true;
+	});
+});
+</script>`
+
+/*
+applyRenderers adds the renderers needed by
+given HTML: KaTeX if it has math, Mermaid if
+it has diagrams.
+
+It returns false if none is needed.
+*/
+func applyRenderers(content string) (string, bool) {
+	var elements strings.Builder
+	if strings.Contains(content, `class="math `) {
+		elements.WriteString(fmt.Sprintf(
+			`<link rel="stylesheet" href="file://%s/katex/katex.min.css">`+
+				`<script src="file://%s/katex/katex.min.js"></script>`,
+			renderersPath,
+			renderersPath,
+		))
+	}
+	if strings.Contains(content, `class="mermaid"`) {
+		elements.WriteString(fmt.Sprintf(`<script src="file://%s/mermaid/mermaid.min.js"></script>`, renderersPath))
+	}
+	if elements.Len() == 0 {
+		return content, false
+	}
+	elements.WriteString(renderersScript)
+	return insertInHead(content, elements.String()), true
+}
+
+/*
+renderersReadiness returns the expression to
+wait for before printing the page: the renderers
+have to be done, on top of the given expression
+(if any).
+*/
+func renderersReadiness(expression string) string {
+	if expression == "" {
+		return renderersReadyExpression
+	}
+	return fmt.Sprintf("(%s) && (%s)", renderersReadyExpression, expression)
+}
+
+// mathSpan is a LaTeX formula
+// of a Markdown file.
+type mathSpan struct {
+	tex     string
+	display bool
+}
+
+func mathPlaceholder(i int) string {
+	return fmt.Sprintf("gotenbergmath%dplaceholder", i)
+}
+
+// nolint: gochecknoglobals
+var (
+	mathPlaceholderRegexp = regexp.MustCompile(`gotenbergmath(\d+)placeholder`)
+	// mathRestoreRegexp matches the tags, so
+	// that the placeholders in their attributes
+	// (e.g. the heading IDs) are not restored.
+	mathRestoreRegexp = regexp.MustCompile(`<[^>]*>|gotenbergmath(\d+)placeholder`)
+)
+
+/*
+extractMath replaces the $...$ (inline) and
+$$...$$ (display) formulas of given Markdown
+with placeholders, so that the Markdown
+processor leaves them untouched.
+
+The formulas in code blocks and code
+spans are not extracted. An inline formula
+does not start or end with a space, and is
+not followed by a digit (e.g. "$5 and $10").
+*/
+func extractMath(text []byte) ([]byte, []mathSpan) {
+	var (
+		out   bytes.Buffer
+		spans []mathSpan
+		fence string
+	)
+	lineStart := true
+	for i := 0; i < len(text); {
+		if lineStart {
+			end := bytes.IndexByte(text[i:], '\n')
+			if end < 0 {
+				end = len(text) - i
+			} else {
+				end++
+			}
+			line := text[i : i+end]
+			trimmed := strings.TrimLeft(string(line), " ")
+			isFence := strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")
+			if fence != "" || isFence {
+				switch {
+				case fence == "":
+					fence = trimmed[:3]
+				case strings.HasPrefix(trimmed, fence):
+					fence = ""
+				}
+				out.Write(line)
+				i += end
+				continue
+			}
+			lineStart = false
+		}
+		c := text[i]
+		switch {
+		case c == '\n':
+			out.WriteByte(c)
+			i++
+			lineStart = true
+		case c == '\\' && i+1 < len(text) && text[i+1] != '\n':
+			out.Write(text[i : i+2])
+			i += 2
+		case c == '`':
+			n := 1
+			for i+n < len(text) && text[i+n] == '`' {
+				n++
+			}
+			delimiter := bytes.Repeat([]byte("`"), n)
+			end := bytes.Index(text[i+n:], delimiter)
+			if end < 0 {
+				out.Write(delimiter)
+				i += n
+				continue
+			}
+			out.Write(text[i : i+n+end+n])
+			i += n + end + n
+		case c == '$' && i+1 < len(text) && text[i+1] == '$':
+			end := bytes.Index(text[i+2:], []byte("$$"))
+			if end < 0 {
+				out.WriteString("$$")
+				i += 2
+				continue
+			}
+			tex := strings.TrimSpace(string(text[i+2 : i+2+end]))
+			out.WriteString(mathPlaceholder(len(spans)))
+			spans = append(spans, mathSpan{tex: tex, display: true})
+			i += 2 + end + 2
+		case c == '$':
+			end := inlineMathEnd(text[i+1:])
+			if end < 0 {
+				out.WriteByte(c)
+				i++
+				continue
+			}
+			out.WriteString(mathPlaceholder(len(spans)))
+			spans = append(spans, mathSpan{tex: string(text[i+1 : i+1+end])})
+			i += 1 + end + 1
+		default:
+			out.WriteByte(c)
+			i++
+		}
+	}
+	return out.Bytes(), spans
+}
+
+// inlineMathEnd returns the index of the closing
+// "$" of an inline formula, or -1 if there is none.
+func inlineMathEnd(text []byte) int {
+	if len(text) == 0 || unicode.IsSpace(rune(text[0])) {
+		return -1
+	}
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\n':
+			return -1
+		case '\\':
+			i++
+		case '$':
+			if unicode.IsSpace(rune(text[i-1])) {
+				return -1
+			}
+			if i+1 < len(text) && text[i+1] >= '0' && text[i+1] <= '9' {
+				return -1
+			}
+			return i
+		}
+	}
+	return -1
+}
+
+/*
+restoreMath replaces the placeholders of given
+HTML with the formulas, in span elements with
+the classes "math math-inline" or
+"math math-display".
+
+The placeholders in the attributes, like the
+generated heading IDs, are replaced with "math".
+*/
+func restoreMath(content []byte, spans []mathSpan) []byte {
+	return mathRestoreRegexp.ReplaceAllFunc(content, func(match []byte) []byte {
+		if match[0] == '<' {
+			return mathPlaceholderRegexp.ReplaceAll(match, []byte("math"))
+		}
+		i, err := strconv.Atoi(string(mathPlaceholderRegexp.FindSubmatch(match)[1]))
+		if err != nil || i >= len(spans) {
+			return match
+		}
+		class := "math math-inline"
+		if spans[i].display {
+			class = "math math-display"
+		}
+		return []byte(fmt.Sprintf(`<span class="%s">%s</span>`, class, html.EscapeString(spans[i].tex)))
+	})
+}
@@ -0,0 +1,84 @@
+package printer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractMath(t *testing.T) {
+	md := "Inline $a_1 * b_2$ and display:\n\n" +
+		"$$\n\\frac{1}{2}\n$$\n\n" +
+		"It costs $5 and $10, or \\$ 3.\n\n" +
+		"`$code$` and ``$code$``\n\n" +
+		"```\n$x$\n```\n"
+	result, spans := extractMath([]byte(md))
+	expected := "Inline gotenbergmath0placeholder and display:\n\n" +
+		"gotenbergmath1placeholder\n\n" +
+		"It costs $5 and $10, or \\$ 3.\n\n" +
+		"`$code$` and ``$code$``\n\n" +
+		"```\n$x$\n```\n"
+	assert.Equal(t, expected, string(result))
+	assert.Equal(t, []mathSpan{
+		{tex: "a_1 * b_2"},
+		{tex: "\\frac{1}{2}", display: true},
+	}, spans)
+	// no closing delimiter.
+	result, spans = extractMath([]byte("$a\nb$ and $$c"))
+	assert.Equal(t, "$a\nb$ and $$c", string(result))
+	assert.Equal(t, 0, len(spans))
+}
+
+func TestRestoreMath(t *testing.T) {
+	spans := []mathSpan{
+		{tex: "a < b"},
+		{tex: "\\frac{1}{2}", display: true},
+	}
+	result := restoreMath([]byte("<p>gotenbergmath0placeholder</p><p>gotenbergmath1placeholder</p>"), spans)
+	expected := `<p><span class="math math-inline">a &lt; b</span></p>` +
+		`<p><span class="math math-display">\frac{1}{2}</span></p>`
+	assert.Equal(t, expected, string(result))
+	// every occurrence, but not
+	// in the attributes.
+	result = restoreMath([]byte(`<h1 id="gotenbergmath0placeholder">gotenbergmath0placeholder</h1>gotenbergmath0placeholder`), spans)
+	expected = `<h1 id="math"><span class="math math-inline">a &lt; b</span></h1>` +
+		`<span class="math math-inline">a &lt; b</span>`
+	assert.Equal(t, expected, string(result))
+}
+
+func TestMarkdownMath(t *testing.T) {
+	b, spans := extractMath([]byte("# Title\n\n$x_1 * y_2$ and ```mermaid``` below.\n\n```mermaid\ngraph LR\n    A --> B\n```\n"))
+	result := string(restoreMath(markdownPolicy("ugc").SanitizeBytes(renderMarkdown(b)), spans))
+	assert.Contains(t, result, `<p><span class="math math-inline">x_1 * y_2</span> and`)
+	assert.Contains(t, result, "<div class=\"mermaid\">graph LR\n    A --&gt; B\n</div>")
+	// math in a heading.
+	b, spans = extractMath([]byte("# Euler $e^{i\\pi}$ rocks\n"))
+	result = string(restoreMath(markdownPolicy("ugc").SanitizeBytes(renderMarkdown(b)), spans))
+	assert.Equal(t, `<h1 id="euler-math-rocks">Euler <span class="math math-inline">e^{i\pi}</span> rocks</h1>`+"\n", result)
+}
+
+func TestApplyRenderers(t *testing.T) {
+	// no math nor diagrams.
+	result, ok := applyRenderers("<html><head></head><body></body></html>")
+	assert.Equal(t, false, ok)
+	assert.Equal(t, "<html><head></head><body></body></html>", result)
+	// math.
+	result, ok = applyRenderers(`<html><head></head><body><span class="math math-inline">x</span></body></html>`)
+	assert.Equal(t, true, ok)
+	assert.Contains(t, result, `<head><link rel="stylesheet" href="file:///opt/gotenberg/renderers/katex/katex.min.css">`)
+	assert.NotContains(t, result, "mermaid.min.js")
+	// diagrams.
+	result, ok = applyRenderers(`<div class="mermaid">graph LR</div>`)
+	assert.Equal(t, true, ok)
+	assert.Contains(t, result, `<script src="file:///opt/gotenberg/renderers/mermaid/mermaid.min.js"></script><script>`)
+	assert.NotContains(t, result, "katex.min.js")
+}
+
+func TestRenderersReadiness(t *testing.T) {
+	assert.Equal(t, "window.gotenbergRenderersReady === true", renderersReadiness(""))
+	assert.Equal(
+		t,
+		"(window.gotenbergRenderersReady === true) && (window.status === 'ready')",
+		renderersReadiness("window.status === 'ready'"),
+	)
+}
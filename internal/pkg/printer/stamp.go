@@ -0,0 +1,365 @@
+package printer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xexec"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xlog"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xrand"
+)
+
+// StampOptions helps customizing the page
+// numbers stamped on each page of a PDF.
+type StampOptions struct {
+	Format      string
+	StartNumber int64
+	Position    string
+	Font        string
+	FontSize    float64
+}
+
+// DefaultStampOptions returns the default
+// stamp options.
+func DefaultStampOptions() StampOptions {
+	return StampOptions{
+		Format:      "",
+		StartNumber: 1,
+		Position:    "bottom-center",
+		Font:        "Helvetica",
+		FontSize:    10.0,
+	}
+}
+
+// StampPositions returns the
+// available stamp positions.
+func StampPositions() []string {
+	return []string{
+		"top-left",
+		"top-center",
+		"top-right",
+		"bottom-left",
+		"bottom-center",
+		"bottom-right",
+	}
+}
+
+// StampFonts returns the
+// available stamp fonts.
+func StampFonts() []string {
+	return []string{
+		"Helvetica",
+		"Times-Roman",
+		"Courier",
+	}
+}
+
+// stampMargin is the distance between the
+// stamp and the edges of a page, in points.
+const stampMargin float64 = 36.0
+
+/*
+stamp stamps the page numbers on each
+page of the PDF file at given path.
+
+It does nothing if the format is empty.
+*/
+func stamp(ctx context.Context, logger xlog.Logger, fpath string, opts StampOptions) error {
+	const op string = "printer.stamp"
+	if opts.Format == "" {
+		logger.DebugOp(op, "no stamp format, moving on...")
+		return nil
+	}
+	resolver := func() error {
+		dirPath := filepath.Dir(fpath)
+		dataFpath := filepath.Join(dirPath, fmt.Sprintf("%s.txt", xrand.Get()))
+		if err := xexec.Run(ctx, logger, "pdftk", fpath, "dump_data_utf8", "output", dataFpath); err != nil {
+			return err
+		}
+		defer os.Remove(dataFpath) // nolint: errcheck
+		data, err := ioutil.ReadFile(dataFpath)
+		if err != nil {
+			return err
+		}
+		pages := parsePageMedia(data)
+		logger.DebugOpf(op, "stamping '%d' pages with '%s'...", len(pages), opts.Format)
+		stampFpath := filepath.Join(dirPath, fmt.Sprintf("%s.pdf", xrand.Get()))
+		if err := ioutil.WriteFile(stampFpath, stampPDF(pages, opts), 0600); err != nil {
+			return err
+		}
+		defer os.Remove(stampFpath) // nolint: errcheck
+		return runPdftkInPlace(ctx, logger, fpath, "multistamp", stampFpath)
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+// pageMedia is the geometry
+// of a page of a PDF.
+type pageMedia struct {
+	rect     [4]float64
+	rotation int
+}
+
+/*
+parsePageMedia returns the geometry of each
+page from the output of pdftk "dump_data".
+
+Pages without geometry fall back on A4.
+*/
+func parsePageMedia(data []byte) []pageMedia {
+	var (
+		numberOfPages int
+		pages         = make(map[int]pageMedia)
+		current       int
+	)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		i := strings.Index(line, ":")
+		if i < 0 {
+			continue
+		}
+		key, value := line[:i], strings.TrimSpace(line[i+1:])
+		switch key {
+		case "NumberOfPages":
+			numberOfPages, _ = strconv.Atoi(value)
+		case "PageMediaNumber":
+			current, _ = strconv.Atoi(value)
+		case "PageMediaRotation":
+			media := pages[current]
+			media.rotation, _ = strconv.Atoi(value)
+			pages[current] = media
+		case "PageMediaRect":
+			fields := strings.Fields(value)
+			if len(fields) != 4 {
+				continue
+			}
+			media := pages[current]
+			for j, field := range fields {
+				media.rect[j], _ = strconv.ParseFloat(field, 64)
+			}
+			pages[current] = media
+		}
+	}
+	result := make([]pageMedia, numberOfPages)
+	for i := range result {
+		media, ok := pages[i+1]
+		if !ok || media.rect[2] <= media.rect[0] || media.rect[3] <= media.rect[1] {
+			media.rect = [4]float64{0, 0, 595.28, 841.89}
+		}
+		result[i] = media
+	}
+	return result
+}
+
+// nolint: gochecknoglobals
+var stampPlaceholderRegexp = regexp.MustCompile(`\{(page|total)(?::(\d+))?\}`)
+
+/*
+formatStamp replaces the placeholders {page}
+and {total} of given format. A width pads the
+number with zeros (e.g. {page:6}).
+*/
+func formatStamp(format string, page, total int64) string {
+	return stampPlaceholderRegexp.ReplaceAllStringFunc(format, func(placeholder string) string {
+		matches := stampPlaceholderRegexp.FindStringSubmatch(placeholder)
+		value := page
+		if matches[1] == "total" {
+			value = total
+		}
+		width, _ := strconv.Atoi(matches[2])
+		return fmt.Sprintf("%0*d", width, value)
+	})
+}
+
+/*
+stampPDF returns a PDF with a page for each given
+page geometry, containing only the stamp.
+
+Only the ASCII characters are supported, as
+the standard fonts are not embedded.
+*/
+func stampPDF(pages []pageMedia, opts StampOptions) []byte {
+	var objects []string
+	objects = append(objects, "<< /Type /Catalog /Pages 2 0 R >>")
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 4+2*i)
+	}
+	objects = append(
+		objects,
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)),
+		fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", opts.Font),
+	)
+	for i, media := range pages {
+		text := toASCII(formatStamp(opts.Format, opts.StartNumber+int64(i), int64(len(pages))))
+		content := stampContent(media, text, opts)
+		objects = append(
+			objects,
+			fmt.Sprintf(
+				"<< /Type /Page /Parent 2 0 R /MediaBox [%s] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+				formatFloats(media.rect[:]...),
+				5+2*i,
+			),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+		)
+	}
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buf.Len()
+		buf.WriteString(fmt.Sprintf("%d 0 obj\n%s\nendobj\n", i+1, object))
+	}
+	xref := buf.Len()
+	buf.WriteString(fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", len(objects)+1))
+	for _, offset := range offsets {
+		buf.WriteString(fmt.Sprintf("%010d 00000 n \n", offset))
+	}
+	buf.WriteString(fmt.Sprintf("trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref))
+	return buf.Bytes()
+}
+
+/*
+stampContent returns the content stream which
+draws given text on a page.
+
+The position is computed as displayed, i.e.
+once the page rotation has been applied.
+*/
+func stampContent(media pageMedia, text string, opts StampOptions) string {
+	x0, y0 := media.rect[0], media.rect[1]
+	width, height := media.rect[2]-x0, media.rect[3]-y0
+	rotation := ((media.rotation % 360) + 360) % 360
+	displayedWidth, displayedHeight := width, height
+	if rotation == 90 || rotation == 270 {
+		displayedWidth, displayedHeight = height, width
+	}
+	textWidth := stringWidth(text, opts.Font, opts.FontSize)
+	var x, y float64
+	switch {
+	case strings.HasSuffix(opts.Position, "-left"):
+		x = stampMargin
+	case strings.HasSuffix(opts.Position, "-right"):
+		x = displayedWidth - stampMargin - textWidth
+	default:
+		x = (displayedWidth - textWidth) / 2
+	}
+	if strings.HasPrefix(opts.Position, "top-") {
+		y = displayedHeight - stampMargin - opts.FontSize
+	} else {
+		y = stampMargin
+	}
+	// from the displayed coordinates to the page coordinates.
+	var matrix [6]float64
+	switch rotation {
+	case 90:
+		matrix = [6]float64{0, 1, -1, 0, width - y, x}
+	case 180:
+		matrix = [6]float64{-1, 0, 0, -1, width - x, height - y}
+	case 270:
+		matrix = [6]float64{0, -1, 1, 0, y, height - x}
+	default:
+		matrix = [6]float64{1, 0, 0, 1, x, y}
+	}
+	matrix[4] += x0
+	matrix[5] += y0
+	return fmt.Sprintf(
+		"BT /F1 %s Tf %s Tm (%s) Tj ET",
+		formatFloats(opts.FontSize),
+		formatFloats(matrix[:]...),
+		escapePDFString(text),
+	)
+}
+
+func formatFloats(values ...float64) string {
+	result := make([]string, len(values))
+	for i, value := range values {
+		result[i] = strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	return strings.Join(result, " ")
+}
+
+func toASCII(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		if r < 32 || r > 126 {
+			r = '?'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func escapePDFString(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`)
+	return replacer.Replace(text)
+}
+
+// stringWidth returns the width of given
+// ASCII text, in points.
+func stringWidth(text, font string, fontSize float64) float64 {
+	var width int
+	for _, r := range text {
+		width += glyphWidth(r, font)
+	}
+	return float64(width) * fontSize / 1000
+}
+
+/*
+glyphWidth returns the width of the glyph of given
+ASCII character, in thousandths of the font size.
+
+See the Adobe Font Metrics of the standard fonts.
+*/
+func glyphWidth(r rune, font string) int {
+	if r < 32 || r > 126 {
+		r = '?'
+	}
+	switch font {
+	case "Courier":
+		return 600
+	case "Times-Roman":
+		return timesRomanWidths[r-32]
+	default:
+		return helveticaWidths[r-32]
+	}
+}
+
+// nolint: gochecknoglobals
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278, // space to /
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, // 0 to 9
+	278, 278, 584, 584, 584, 556, 1015, // : to @
+	667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, // A to M
+	722, 778, 667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, // N to Z
+	278, 278, 278, 469, 556, 333, // [ to `
+	556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, // a to m
+	556, 556, 556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, // n to z
+	334, 260, 334, 584, // { to ~
+}
+
+// nolint: gochecknoglobals
+var timesRomanWidths = [95]int{
+	250, 333, 408, 500, 500, 833, 778, 180, 333, 333, 500, 564, 250, 333, 250, 278, // space to /
+	500, 500, 500, 500, 500, 500, 500, 500, 500, 500, // 0 to 9
+	278, 278, 564, 564, 564, 444, 921, // : to @
+	722, 667, 667, 722, 611, 556, 722, 722, 333, 389, 722, 611, 889, // A to M
+	722, 722, 556, 722, 667, 556, 611, 722, 722, 944, 722, 722, 611, // N to Z
+	333, 278, 333, 469, 500, 333, // [ to `
+	444, 500, 444, 500, 444, 333, 500, 500, 278, 278, 500, 278, 778, // a to m
+	500, 500, 500, 500, 333, 389, 278, 500, 500, 722, 500, 500, 444, // n to z
+	480, 200, 480, 541, // { to ~
+}
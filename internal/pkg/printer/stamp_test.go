@@ -0,0 +1,92 @@
+package printer
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatStamp(t *testing.T) {
+	assert.Equal(t, "Page 3 of 12", formatStamp("Page {page} of {total}", 3, 12))
+	assert.Equal(t, "ACME-000042", formatStamp("ACME-{page:6}", 42, 100))
+	assert.Equal(t, "1234567", formatStamp("{page:3}", 1234567, 1))
+	assert.Equal(t, "{pages} {page:x}", formatStamp("{pages} {page:x}", 1, 1))
+}
+
+func TestParsePageMedia(t *testing.T) {
+	data := []byte("InfoBegin\n" +
+		"InfoKey: Title\n" +
+		"InfoValue: Page: 1\n" +
+		"NumberOfPages: 3\n" +
+		"PageMediaBegin\n" +
+		"PageMediaNumber: 1\n" +
+		"PageMediaRotation: 0\n" +
+		"PageMediaRect: 0 0 612 792\n" +
+		"PageMediaDimensions: 612 792\n" +
+		"PageMediaBegin\n" +
+		"PageMediaNumber: 2\n" +
+		"PageMediaRotation: 90\n" +
+		"PageMediaRect: 10 20 622 812\n" +
+		"PageMediaDimensions: 612 792\n")
+	expected := []pageMedia{
+		{rect: [4]float64{0, 0, 612, 792}, rotation: 0},
+		{rect: [4]float64{10, 20, 622, 812}, rotation: 90},
+		{rect: [4]float64{0, 0, 595.28, 841.89}, rotation: 0},
+	}
+	assert.Equal(t, expected, parsePageMedia(data))
+	assert.Equal(t, []pageMedia{}, parsePageMedia(nil))
+}
+
+func TestStampContent(t *testing.T) {
+	opts := DefaultStampOptions()
+	media := pageMedia{rect: [4]float64{0, 0, 600, 800}}
+	// "10" is 2 * 556 / 1000 * 10 = 11.12 points wide.
+	assert.Equal(t, "BT /F1 10 Tf 1 0 0 1 294.44 36 Tm (10) Tj ET", stampContent(media, "10", opts))
+	opts.Position = "top-right"
+	assert.Equal(t, "BT /F1 10 Tf 1 0 0 1 552.88 754 Tm (10) Tj ET", stampContent(media, "10", opts))
+	opts.Position = "bottom-left"
+	opts.Font = "Courier"
+	assert.Equal(t, "BT /F1 10 Tf 1 0 0 1 36 36 Tm (\\(1\\)) Tj ET", stampContent(media, "(1)", opts))
+	// the position is computed as displayed.
+	media.rotation = 90
+	assert.Equal(t, "BT /F1 10 Tf 0 1 -1 0 564 36 Tm (1) Tj ET", stampContent(media, "1", opts))
+	media.rotation = 180
+	assert.Equal(t, "BT /F1 10 Tf -1 0 0 -1 564 764 Tm (1) Tj ET", stampContent(media, "1", opts))
+	media.rotation = 270
+	assert.Equal(t, "BT /F1 10 Tf 0 -1 1 0 36 764 Tm (1) Tj ET", stampContent(media, "1", opts))
+	media.rotation = 0
+	media.rect = [4]float64{10, 20, 610, 820}
+	assert.Equal(t, "BT /F1 10 Tf 1 0 0 1 46 56 Tm (1) Tj ET", stampContent(media, "1", opts))
+}
+
+func TestStampPDF(t *testing.T) {
+	opts := DefaultStampOptions()
+	opts.Format = "Page {page} of {total}"
+	opts.StartNumber = 5
+	pages := []pageMedia{
+		{rect: [4]float64{0, 0, 612, 792}},
+		{rect: [4]float64{0, 0, 792, 612}},
+	}
+	data := stampPDF(pages, opts)
+	assert.Equal(t, true, bytes.HasPrefix(data, []byte("%PDF-1.4\n")))
+	assert.Equal(t, true, bytes.HasSuffix(data, []byte("%%EOF\n")))
+	assert.Equal(t, 2, pdfPageCount(data))
+	assert.Contains(t, string(data), "/BaseFont /Helvetica")
+	assert.Contains(t, string(data), "/MediaBox [0 0 792 612]")
+	assert.Contains(t, string(data), "(Page 5 of 2)")
+	assert.Contains(t, string(data), "(Page 6 of 2)")
+	// startxref points to the cross-reference table.
+	xref := bytes.Index(data, []byte("xref\n"))
+	assert.Equal(t, true, xref > 0)
+	assert.Contains(t, string(data), "startxref\n"+strconv.Itoa(xref)+"\n")
+}
+
+func TestStringWidth(t *testing.T) {
+	assert.Equal(t, 11.12, stringWidth("10", "Helvetica", 10))
+	assert.Equal(t, 10.0, stringWidth("10", "Times-Roman", 10))
+	assert.Equal(t, 18.0, stringWidth("abc", "Courier", 10))
+	// non-ASCII characters are replaced by "?".
+	assert.Equal(t, "Page ?", toASCII("Page é"))
+}
@@ -0,0 +1,203 @@
+package printer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+)
+
+/*
+templateFuncs returns the functions available
+in the templates executed with JSON data.
+
+They only format the data: none of them has
+access to the file system or the network.
+*/
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"formatDate":     formatDate,
+		"formatNumber":   formatNumber,
+		"formatCurrency": formatCurrency,
+		"upper":          strings.ToUpper,
+		"lower":          strings.ToLower,
+		"default":        defaultValue,
+	}
+}
+
+/*
+executeTemplate executes given HTML template
+with given data.
+
+As the template comes from the request, an error
+while parsing or executing it is an invalid
+request.
+*/
+func executeTemplate(name, content string, data interface{}) (string, error) {
+	const op string = "printer.executeTemplate"
+	tmpl, err := template.New(name).Funcs(templateFuncs()).Parse(content)
+	if err != nil {
+		return "", xerror.Invalid(op, fmt.Sprintf("'%s' is not a valid template: %s", name, err.Error()), err)
+	}
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, data); err != nil {
+		return "", xerror.Invalid(op, fmt.Sprintf("failed to execute template '%s': %s", name, err.Error()), err)
+	}
+	return buffer.String(), nil
+}
+
+// dateLayouts are the layouts accepted
+// for the dates given as strings.
+// nolint: gochecknoglobals
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+/*
+formatDate formats given date with given
+Go layout (e.g. "02/01/2006").
+
+The date is either a time.Time, a string
+(RFC 3339 or "2006-01-02") or a Unix
+timestamp in seconds.
+*/
+func formatDate(layout string, value interface{}) (string, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(layout), nil
+	case string:
+		for _, dateLayout := range dateLayouts {
+			t, err := time.Parse(dateLayout, v)
+			if err == nil {
+				return t.Format(layout), nil
+			}
+		}
+		return "", fmt.Errorf("'%s' is not a valid date", v)
+	default:
+		seconds, err := toFloat64(value)
+		if err != nil {
+			return "", err
+		}
+		return time.Unix(int64(seconds), 0).UTC().Format(layout), nil
+	}
+}
+
+/*
+formatNumber formats given number with given
+number of decimals, a comma as thousands
+separator and a dot as decimal separator
+(e.g. 1,234.50).
+*/
+func formatNumber(decimals int, value interface{}) (string, error) {
+	number, err := toFloat64(value)
+	if err != nil {
+		return "", err
+	}
+	if decimals < 0 {
+		decimals = 0
+	}
+	formatted := strconv.FormatFloat(math.Abs(number), 'f', decimals, 64)
+	integer, fraction := formatted, ""
+	if i := strings.Index(formatted, "."); i >= 0 {
+		integer, fraction = formatted[:i], formatted[i:]
+	}
+	var b strings.Builder
+	if number < 0 && strings.Trim(formatted, "0.") != "" {
+		b.WriteString("-")
+	}
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(",")
+		}
+		b.WriteRune(digit)
+	}
+	b.WriteString(fraction)
+	return b.String(), nil
+}
+
+// currencies are the symbols and the number
+// of decimals of the common currencies.
+// nolint: gochecknoglobals
+var currencies = map[string]struct {
+	symbol   string
+	decimals int
+}{
+	"USD": {"$", 2},
+	"EUR": {"€", 2},
+	"GBP": {"£", 2},
+	"JPY": {"¥", 0},
+	"CHF": {"CHF ", 2},
+	"CAD": {"CA$", 2},
+	"AUD": {"A$", 2},
+	"CNY": {"CN¥", 2},
+	"INR": {"₹", 2},
+	"BRL": {"R$", 2},
+}
+
+/*
+formatCurrency formats given amount with the
+symbol of given ISO 4217 currency code
+(e.g. $1,234.50).
+
+Unknown currency codes are appended to the
+amount, with two decimals (e.g. 1,234.50 SEK).
+*/
+func formatCurrency(code string, value interface{}) (string, error) {
+	code = strings.ToUpper(code)
+	currency, ok := currencies[code]
+	if !ok {
+		amount, err := formatNumber(2, value)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s %s", amount, code), nil
+	}
+	amount, err := formatNumber(currency.decimals, value)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(amount, "-") {
+		return fmt.Sprintf("-%s%s", currency.symbol, amount[1:]), nil
+	}
+	return fmt.Sprintf("%s%s", currency.symbol, amount), nil
+}
+
+// defaultValue returns the given default
+// value if given value is empty.
+func defaultValue(defaultValue, value interface{}) interface{} {
+	if value == nil {
+		return defaultValue
+	}
+	if s, ok := value.(string); ok && s == "" {
+		return defaultValue
+	}
+	return value
+}
+
+func toFloat64(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case json.Number:
+		return v.Float64()
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	default:
+		return 0, fmt.Errorf("'%v' is not a number", value)
+	}
+}
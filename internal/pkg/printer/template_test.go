@@ -0,0 +1,114 @@
+package printer
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/test"
+)
+
+// invoiceData returns the content of
+// the file "testdata/template/data.json".
+func invoiceData(t *testing.T) interface{} {
+	b, err := ioutil.ReadFile(test.TemplateFpath(t, "data.json"))
+	require.Nil(t, err)
+	decoder := json.NewDecoder(strings.NewReader(string(b)))
+	decoder.UseNumber()
+	var data interface{}
+	require.Nil(t, decoder.Decode(&data))
+	return data
+}
+
+func TestExecuteTemplate(t *testing.T) {
+	b, err := ioutil.ReadFile(test.TemplateFpath(t, "index.html"))
+	require.Nil(t, err)
+	html, err := executeTemplate("index.html", string(b), invoiceData(t))
+	assert.Nil(t, err)
+	assert.Contains(t, html, "<title>Invoice 2020-0042</title>")
+	assert.Contains(t, html, "Issued on April 20, 2020 to JOHANNES GUTENBERG.")
+	assert.Contains(t, html, "<td>2,500</td>")
+	assert.Contains(t, html, "<td>€1,234.50</td>")
+	assert.Contains(t, html, "Total: €3,109.50")
+	assert.Contains(t, html, "No notes.")
+	// the data is escaped.
+	html, err = executeTemplate("foo.html", "<p>{{ .name }}</p>", map[string]interface{}{"name": "<script>alert(1)</script>"})
+	assert.Nil(t, err)
+	assert.Equal(t, "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>", html)
+	// should not be OK as the
+	// template is invalid.
+	_, err = executeTemplate("foo.html", "{{ .name ", nil)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	// should not be OK as the
+	// template execution fails.
+	_, err = executeTemplate("foo.html", `{{ formatNumber 2 .total }}`, map[string]interface{}{"total": "foo"})
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+}
+
+func TestFormatDate(t *testing.T) {
+	v, err := formatDate("02/01/2006", "2020-04-20")
+	assert.Nil(t, err)
+	assert.Equal(t, "20/04/2020", v)
+	v, err = formatDate("2006-01-02 15:04", "2020-04-20T10:30:00+02:00")
+	assert.Nil(t, err)
+	assert.Equal(t, "2020-04-20 10:30", v)
+	v, err = formatDate("2006-01-02", json.Number("1587340800"))
+	assert.Nil(t, err)
+	assert.Equal(t, "2020-04-20", v)
+	v, err = formatDate("2006", time.Date(2020, 4, 20, 0, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+	assert.Equal(t, "2020", v)
+	_, err = formatDate("2006", "foo")
+	assert.NotNil(t, err)
+	_, err = formatDate("2006", true)
+	assert.NotNil(t, err)
+}
+
+func TestFormatNumber(t *testing.T) {
+	for _, tc := range []struct {
+		decimals int
+		value    interface{}
+		expected string
+	}{
+		{2, json.Number("1234567.891"), "1,234,567.89"},
+		{0, 999.0, "999"},
+		{0, 1000, "1,000"},
+		{1, "-1234.56", "-1,234.6"},
+		{2, -0.001, "0.00"},
+		{-1, int64(12), "12"},
+	} {
+		v, err := formatNumber(tc.decimals, tc.value)
+		assert.Nil(t, err)
+		assert.Equal(t, tc.expected, v)
+	}
+	_, err := formatNumber(2, "foo")
+	assert.NotNil(t, err)
+}
+
+func TestFormatCurrency(t *testing.T) {
+	v, err := formatCurrency("usd", 1234.5)
+	assert.Nil(t, err)
+	assert.Equal(t, "$1,234.50", v)
+	v, err = formatCurrency("JPY", json.Number("-1234.5"))
+	assert.Nil(t, err)
+	assert.Equal(t, "-¥1,234", v)
+	v, err = formatCurrency("SEK", 1234.5)
+	assert.Nil(t, err)
+	assert.Equal(t, "1,234.50 SEK", v)
+	_, err = formatCurrency("EUR", nil)
+	assert.NotNil(t, err)
+}
+
+func TestDefaultValue(t *testing.T) {
+	assert.Equal(t, "foo", defaultValue("foo", nil))
+	assert.Equal(t, "foo", defaultValue("foo", ""))
+	assert.Equal(t, "bar", defaultValue("foo", "bar"))
+	assert.Equal(t, 0, defaultValue("foo", 0))
+}
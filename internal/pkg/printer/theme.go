@@ -0,0 +1,79 @@
+package printer
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// MarkdownThemes returns the available
+// themes for Markdown conversions.
+func MarkdownThemes() []string {
+	return []string{"none", "github", "book"}
+}
+
+const highlightCSS string = `
+.highlight .hl-keyword { color: #d73a49; font-weight: bold; }
+.highlight .hl-literal, .highlight .hl-number { color: #005cc5; }
+.highlight .hl-string { color: #032f62; }
+.highlight .hl-comment { color: #6a737d; font-style: italic; }
+.highlight .hl-function { color: #6f42c1; }
+`
+
+const commonCSS string = `
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { border: 1px solid #dfe2e5; padding: 6px 13px; }
+tr:nth-child(2n) { background-color: #f6f8fa; }
+pre { padding: 16px; overflow: hidden; white-space: pre-wrap; page-break-inside: avoid; }
+code { font-family: "DejaVu Sans Mono", Menlo, Consolas, monospace; font-size: 85%; }
+li.task-list-item { list-style-type: none; }
+li.task-list-item input { margin: 0 0.3em 0 -1.4em; }
+.footnotes { font-size: 85%; }
+img { max-width: 100%; }
+` + highlightCSS
+
+// nolint: gochecknoglobals
+var themesCSS = map[string]string{
+	"github": `
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; font-size: 16px; line-height: 1.5; color: #24292e; }
+h1, h2 { padding-bottom: 0.3em; border-bottom: 1px solid #eaecef; }
+a { color: #0366d6; text-decoration: none; }
+blockquote { margin: 0; padding: 0 1em; color: #6a737d; border-left: 0.25em solid #dfe2e5; }
+pre, code { background-color: #f6f8fa; border-radius: 3px; }
+` + commonCSS,
+	"book": `
+body { font-family: Georgia, "DejaVu Serif", "Times New Roman", serif; font-size: 12pt; line-height: 1.6; color: #111; text-align: justify; }
+h1, h2, h3, h4, h5, h6 { font-weight: normal; page-break-after: avoid; }
+h1 { text-align: center; }
+a { color: inherit; }
+blockquote { margin: 1em 2em; font-style: italic; }
+pre { border-left: 3px solid #ccc; background-color: #fafafa; }
+` + commonCSS,
+}
+
+// nolint: gochecknoglobals
+var headRegexp = regexp.MustCompile(`(?i)<head\b[^>]*>`)
+
+/*
+applyMarkdownTheme adds the stylesheet of given
+theme to given HTML.
+
+The stylesheet is added at the beginning of the
+head element, so that the stylesheets of the
+document take precedence.
+*/
+func applyMarkdownTheme(theme, html string) string {
+	css, ok := themesCSS[theme]
+	if !ok {
+		return html
+	}
+	return insertInHead(html, fmt.Sprintf("<style>%s</style>", css))
+}
+
+// insertInHead adds given elements at the
+// beginning of the head element of given HTML.
+func insertInHead(html, elements string) string {
+	if loc := headRegexp.FindStringIndex(html); loc != nil {
+		return html[:loc[1]] + elements + html[loc[1]:]
+	}
+	return elements + html
+}
@@ -0,0 +1,27 @@
+package printer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyMarkdownTheme(t *testing.T) {
+	html := `<html><HEAD lang="en"><link rel="stylesheet" href="style.css"></HEAD><body></body></html>`
+	// no theme.
+	assert.Equal(t, html, applyMarkdownTheme("none", html))
+	// theme with a head element.
+	result := applyMarkdownTheme("github", html)
+	assert.Equal(t, true, strings.HasPrefix(result, `<html><HEAD lang="en"><style>`))
+	assert.Equal(t, true, strings.HasSuffix(result, `</style><link rel="stylesheet" href="style.css"></HEAD><body></body></html>`))
+	// theme without a head element.
+	result = applyMarkdownTheme("book", "<p>foo</p>")
+	assert.Equal(t, true, strings.HasPrefix(result, "<style>"))
+	assert.Equal(t, true, strings.HasSuffix(result, "</style><p>foo</p>"))
+	// every theme has a stylesheet.
+	for _, theme := range MarkdownThemes() {
+		_, ok := themesCSS[theme]
+		assert.Equal(t, theme != "none", ok)
+	}
+}
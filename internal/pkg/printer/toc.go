@@ -0,0 +1,307 @@
+package printer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"math"
+	"net/url"
+	"regexp"
+	"strconv"
+
+	"github.com/mafredri/cdp"
+	"github.com/mafredri/cdp/protocol/runtime"
+	"github.com/mafredri/cdp/rpcc"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+)
+
+/*
+tocPlaceholder is the element replaced by the
+table of contents.
+
+Without such an element in the page, the table
+of contents is added at the beginning of the body.
+*/
+const tocPlaceholder template.HTML = `<nav class="toc" data-gotenberg-toc></nav>`
+
+// tocCSS is the default stylesheet of the
+// table of contents, added before the
+// stylesheets of the page.
+const tocCSS string = `
+.toc ul { list-style: none; margin: 0; padding: 0; }
+.toc li.toc-level-2 { padding-left: 1.5em; }
+.toc li.toc-level-3 { padding-left: 3em; }
+.toc a { display: flex; color: inherit; text-decoration: none; }
+.toc .toc-title { flex: 1 1 auto; }
+.toc .toc-page { min-width: 2em; margin-left: 1em; text-align: right; }
+`
+
+// tocEntry is a heading listed in the table
+// of contents, with its position (in CSS pixels).
+type tocEntry struct {
+	ID  string  `json:"id"`
+	Top float64 `json:"top"`
+}
+
+/*
+tocExpression fills the table of contents with
+a link to each h1-h3 element of the page, and
+returns the corresponding entries.
+
+The headings without an id get one, so that
+they may be linked.
+*/
+func tocExpression() string {
+	css, _ := json.Marshal(tocCSS) // nolint: errcheck
+	return fmt.Sprintf(`(() => {
+	let toc = document.querySelector('[data-gotenberg-toc]');
+	if (!toc) {
+		toc = document.createElement('nav');
+		toc.className = 'toc';
+		toc.setAttribute('data-gotenberg-toc', '');
+		document.body.insertBefore(toc, document.body.firstChild);
+	}
+	const style = document.createElement('style');
+	style.textContent = %s;
+	document.head.insertBefore(style, document.head.firstChild);
+	const list = document.createElement('ul');
+	toc.appendChild(list);
+	const headings = Array.from(document.querySelectorAll('h1, h2, h3'))
+		.filter(h => !toc.contains(h) && h.innerText.trim() !== '');
+	headings.forEach((h, i) => {
+		if (!h.id) {
+			h.id = 'toc-' + (i + 1);
+		}
+		const item = document.createElement('li');
+		item.className = 'toc-level-' + h.tagName.substring(1);
+		const link = document.createElement('a');
+		link.href = '#' + h.id;
+		const title = document.createElement('span');
+		title.className = 'toc-title';
+		title.textContent = h.innerText.trim();
+		const page = document.createElement('span');
+		page.className = 'toc-page';
+		link.append(title, page);
+		item.appendChild(link);
+		list.appendChild(item);
+	});
+	return JSON.stringify(headings.map(h => ({
+		id: h.id,
+		top: h.getBoundingClientRect().top + window.scrollY
+	})));
+})()`, css)
+}
+
+// tocPagesExpression writes given page
+// numbers in the table of contents.
+func tocPagesExpression(pages map[string]int) (string, error) {
+	b, err := json.Marshal(pages)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(`((pages) => {
+	document.querySelectorAll('[data-gotenberg-toc] a').forEach(a => {
+		const page = pages[a.getAttribute('href').substring(1)];
+		if (page) {
+			a.querySelector('.toc-page').textContent = page;
+		}
+	});
+	return '';
+})(%s)`, b), nil
+}
+
+/*
+buildTableOfContents adds the table of contents
+to the page, with the page number of each entry.
+
+It is a two-pass render: the page is printed once
+for finding out on which page each heading lands,
+then the page numbers are written in the table of
+contents before the actual printing.
+*/
+func (p chromePrinter) buildTableOfContents(ctx context.Context, client *cdp.Client, conn *rpcc.Conn) error {
+	const op string = "printer.chromePrinter.buildTableOfContents"
+	resolver := func() error {
+		var entries []tocEntry
+		if err := evaluateJSON(ctx, client, tocExpression(), &entries); err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			p.logger.DebugOp(op, "skipping page numbers as the page has no headings...")
+			return nil
+		}
+		// the page numbers are those of the
+		// whole document, whatever the page ranges.
+		firstPass := p
+		firstPass.opts.PageRanges = ""
+		p.logger.DebugOpf(op, "printing the page for finding out the page numbers of '%d' headings...", len(entries))
+		data, err := firstPass.printToPDF(ctx, conn, p.opts.HeaderHTML, p.opts.FooterHTML)
+		if err != nil {
+			return err
+		}
+		pages := tocPages(entries, namedDestinations(data), p.pageHeight())
+		expression, err := tocPagesExpression(pages)
+		if err != nil {
+			return err
+		}
+		var ignored string
+		return evaluateJSON(ctx, client, expression, &ignored)
+	}
+	if err := resolver(); err != nil {
+		return xerror.New(op, err)
+	}
+	return nil
+}
+
+/*
+tocPages returns the page number of each
+entry of the table of contents.
+
+It relies on the named destinations of the PDF,
+and falls back on the position of the heading
+if there is none.
+*/
+func tocPages(entries []tocEntry, destinations map[string]int, pageHeight float64) map[string]int {
+	pages := make(map[string]int, len(entries))
+	for _, entry := range entries {
+		if page, ok := destinations[entry.ID]; ok {
+			pages[entry.ID] = page
+			continue
+		}
+		pages[entry.ID] = pageNumber(entry.Top, pageHeight)
+	}
+	return pages
+}
+
+// pageNumber returns the number of the page
+// at given position (in CSS pixels).
+func pageNumber(top, pageHeight float64) int {
+	if pageHeight <= 0 || top <= 0 {
+		return 1
+	}
+	return int(math.Floor(top/pageHeight)) + 1
+}
+
+// nolint: gochecknoglobals
+var (
+	pdfPagesRegexp       = regexp.MustCompile(`/Pages\s+(\d+)\s+0\s+R`)
+	pdfDestsRegexp       = regexp.MustCompile(`/Dests\s+(\d+)\s+0\s+R`)
+	pdfKidsRegexp        = regexp.MustCompile(`/Kids\s*\[([^\]]*)\]`)
+	pdfReferenceRegexp   = regexp.MustCompile(`(\d+)\s+0\s+R`)
+	pdfDestinationRegexp = regexp.MustCompile(`/([^\s/\[\]<>()]+)\s*\[\s*(\d+)\s+0\s+R\s*/XYZ`)
+	pdfNameEscapeRegexp  = regexp.MustCompile(`#[0-9A-Fa-f]{2}`)
+)
+
+/*
+namedDestinations returns the page number of each
+named destination of given PDF data.
+
+Google Chrome adds a named destination for each
+element targeted by an internal link, such as the
+headings of the table of contents. It returns an
+empty map if the PDF has no such destinations.
+*/
+func namedDestinations(data []byte) map[string]int {
+	destinations := make(map[string]int)
+	pages := pdfPages(data)
+	matches := pdfDestsRegexp.FindSubmatch(data)
+	if len(pages) == 0 || matches == nil {
+		return destinations
+	}
+	dests, ok := pdfObject(data, string(matches[1]))
+	if !ok {
+		return destinations
+	}
+	for _, match := range pdfDestinationRegexp.FindAllSubmatch(dests, -1) {
+		page, ok := pages[string(match[2])]
+		if !ok {
+			continue
+		}
+		name := pdfNameEscapeRegexp.ReplaceAllFunc(match[1], func(escape []byte) []byte {
+			c, _ := strconv.ParseUint(string(escape[1:]), 16, 8) // nolint: errcheck
+			return []byte{byte(c)}
+		})
+		destinations[string(name)] = page
+		// the links percent-encode the ids.
+		if unescaped, err := url.PathUnescape(string(name)); err == nil {
+			destinations[unescaped] = page
+		}
+	}
+	return destinations
+}
+
+// pdfPages returns the page number of each
+// page object of given PDF data.
+func pdfPages(data []byte) map[string]int {
+	pages := make(map[string]int)
+	matches := pdfPagesRegexp.FindSubmatch(data)
+	if matches == nil {
+		return pages
+	}
+	visited := make(map[string]bool)
+	var walk func(number string)
+	walk = func(number string) {
+		if visited[number] {
+			return
+		}
+		visited[number] = true
+		object, ok := pdfObject(data, number)
+		if !ok {
+			return
+		}
+		kids := pdfKidsRegexp.FindSubmatch(object)
+		if kids == nil {
+			pages[number] = len(pages) + 1
+			return
+		}
+		for _, kid := range pdfReferenceRegexp.FindAllSubmatch(kids[1], -1) {
+			walk(string(kid[1]))
+		}
+	}
+	walk(string(matches[1]))
+	return pages
+}
+
+/*
+pdfObject returns the content of given
+object of given PDF data.
+
+The last definition of the object wins, as
+with the incremental updates of a PDF.
+*/
+func pdfObject(data []byte, number string) ([]byte, bool) {
+	starts := regexp.MustCompile(fmt.Sprintf(`(^|\s)%s\s+0\s+obj\b`, number)).FindAllIndex(data, -1)
+	if starts == nil {
+		return nil, false
+	}
+	object := data[starts[len(starts)-1][1]:]
+	end := regexp.MustCompile(`\bendobj\b|\bstream\b`).FindIndex(object)
+	if end == nil {
+		return nil, false
+	}
+	return object[:end[0]], true
+}
+
+// evaluateJSON evaluates given expression, which
+// returns a JSON string, and decodes its result.
+func evaluateJSON(ctx context.Context, client *cdp.Client, expression string, v interface{}) error {
+	evaluate, err := client.Runtime.Evaluate(
+		ctx,
+		runtime.NewEvaluateArgs(expression).SetReturnByValue(true),
+	)
+	if err != nil {
+		return err
+	}
+	if evaluate.ExceptionDetails != nil {
+		return fmt.Errorf("failed to evaluate expression: %s", evaluate.ExceptionDetails.Text)
+	}
+	var value string
+	if err := json.Unmarshal(evaluate.Result.Value, &value); err != nil {
+		return err
+	}
+	if value == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(value), v)
+}
@@ -0,0 +1,52 @@
+package printer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamedDestinations(t *testing.T) {
+	data := []byte("%PDF-1.4\n" +
+		"1 0 obj\n<</Type /Catalog\n/Pages 2 0 R\n/Dests 9 0 R>>\nendobj\n" +
+		"2 0 obj\n<</Type /Pages\n/Kids [3 0 R 4 0 R]\n/Count 3>>\nendobj\n" +
+		"3 0 obj\n<</Type /Pages\n/Kids [5 0 R 6 0 R]\n/Count 2>>\nendobj\n" +
+		"4 0 obj\n<</Type /Page\n/Parent 2 0 R>>\nendobj\n" +
+		"5 0 obj\n<</Type /Page\n/Parent 3 0 R\n/Contents 7 0 R>>\nendobj\n" +
+		"6 0 obj\n<</Type /Page\n/Parent 3 0 R>>\nendobj\n" +
+		"7 0 obj\n<</Length 10>> stream\n9 0 obj endstream\nendobj\n" +
+		"9 0 obj\n<</introduction [5 0 R /XYZ 0 792 0]\n/conclusion [4 0 R /XYZ 0 400 0]\n" +
+		"/caf#C3#A9 [6 0 R /XYZ 0 792 0]\n/caf%C3%A9-2 [6 0 R /XYZ 0 792 0]\n/unknown [8 0 R /XYZ 0 792 0]>>\nendobj\n")
+	expected := map[string]int{
+		"introduction": 1,
+		"conclusion":   3,
+		"café":         2,
+		"caf%C3%A9-2":  2,
+		"café-2":       2,
+	}
+	assert.Equal(t, expected, namedDestinations(data))
+	assert.Equal(t, map[string]int{}, namedDestinations([]byte("%PDF-1.4\n1 0 obj\n<</Type /Catalog\n/Pages 2 0 R>>\nendobj\n")))
+	assert.Equal(t, map[string]int{}, namedDestinations(nil))
+}
+
+func TestTOCPages(t *testing.T) {
+	entries := []tocEntry{
+		{ID: "introduction", Top: 10},
+		{ID: "usage", Top: 1500},
+		{ID: "conclusion", Top: 2500},
+	}
+	destinations := map[string]int{"conclusion": 4}
+	expected := map[string]int{
+		"introduction": 1,
+		"usage":        2,
+		"conclusion":   4,
+	}
+	assert.Equal(t, expected, tocPages(entries, destinations, 1000))
+	assert.Equal(t, 1, pageNumber(500, 0))
+}
+
+func TestTOCPagesExpression(t *testing.T) {
+	expression, err := tocPagesExpression(map[string]int{"introduction": 2})
+	assert.Nil(t, err)
+	assert.Contains(t, expression, `})({"introduction":2})`)
+}
@@ -7,9 +7,5 @@ import (
 // NewURLPrinter returns a Printer which
 // is able to convert a URL to PDF.
 func NewURLPrinter(logger xlog.Logger, url string, opts ChromePrinterOptions) Printer {
-	return chromePrinter{
-		logger: logger,
-		url:    url,
-		opts:   opts,
-	}
+	return newChromePrinter(logger, url, opts)
 }
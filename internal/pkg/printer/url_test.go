@@ -47,6 +47,40 @@ func TestURLPrinter(t *testing.T) {
 	assert.Nil(t, err)
 	err = os.RemoveAll(dest)
 	assert.Nil(t, err)
+	// options with cookies.
+	opts = DefaultChromePrinterOptions(config)
+	opts.Cookies = []Cookie{
+		{Name: "foo", Value: "bar"},
+		{Name: "baz", Value: "qux", Domain: "google.com", Path: "/", Secure: true, HTTPOnly: true, SameSite: "Lax"},
+	}
+	p = NewURLPrinter(logger, URL, opts)
+	dest = test.GenerateDestination()
+	err = p.Print(dest)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// options with screen media type
+	// and CSS page size.
+	opts = DefaultChromePrinterOptions(config)
+	opts.EmulatedMediaType = "screen"
+	opts.PreferCSSPageSize = true
+	p = NewURLPrinter(logger, URL, opts)
+	dest = test.GenerateDestination()
+	err = p.Print(dest)
+	assert.Nil(t, err)
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
+	// should not be OK as the remote URL
+	// returns a failing HTTP status code.
+	opts = DefaultChromePrinterOptions(config)
+	opts.FailOnHTTPStatusCodes = []int64{499, 599}
+	p = NewURLPrinter(logger, "https://google.com/404", opts)
+	dest = test.GenerateDestination()
+	err = p.Print(dest)
+	test.AssertError(t, err)
+	assert.Equal(t, xerror.InvalidCode, xerror.Code(err))
+	err = os.RemoveAll(dest)
+	assert.Nil(t, err)
 	// should not be OK as options have
 	// a wrong page ranges.
 	opts = DefaultChromePrinterOptions(config)
@@ -0,0 +1,176 @@
+package printer
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/mafredri/cdp/rpcc"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xerror"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xexec"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/xrand"
+)
+
+/*
+HeaderFooterVariants are the header and footer
+templates of specific pages.
+
+An empty template means the default
+header or footer template.
+*/
+type HeaderFooterVariants struct {
+	FirstHeaderHTML string
+	OddHeaderHTML   string
+	EvenHeaderHTML  string
+	FirstFooterHTML string
+	OddFooterHTML   string
+	EvenFooterHTML  string
+}
+
+func (v HeaderFooterVariants) any() bool {
+	return v != HeaderFooterVariants{}
+}
+
+// pageKind is either the first
+// page, an odd page or an even page.
+type pageKind int
+
+const (
+	firstPage pageKind = iota
+	oddPage
+	evenPage
+)
+
+func kindOfPage(pageNumber int) pageKind {
+	if pageNumber == 1 {
+		return firstPage
+	}
+	if pageNumber%2 == 1 {
+		return oddPage
+	}
+	return evenPage
+}
+
+/*
+templates returns the header and footer templates
+of given kind of page.
+
+The first page falls back on the odd page templates,
+which fall back on the default templates.
+*/
+func (p chromePrinter) templates(kind pageKind) (string, string) {
+	v := p.opts.HeaderFooterVariants
+	pick := func(values ...string) string {
+		for _, value := range values {
+			if value != "" {
+				return value
+			}
+		}
+		return ""
+	}
+	switch kind {
+	case firstPage:
+		return pick(v.FirstHeaderHTML, v.OddHeaderHTML, p.opts.HeaderHTML),
+			pick(v.FirstFooterHTML, v.OddFooterHTML, p.opts.FooterHTML)
+	case oddPage:
+		return pick(v.OddHeaderHTML, p.opts.HeaderHTML),
+			pick(v.OddFooterHTML, p.opts.FooterHTML)
+	default:
+		return pick(v.EvenHeaderHTML, p.opts.HeaderHTML),
+			pick(v.EvenFooterHTML, p.opts.FooterHTML)
+	}
+}
+
+// nolint: gochecknoglobals
+var pdfPageRegexp = regexp.MustCompile(`/Type\s*/Page\b`)
+
+// pdfPageCount returns the number of pages
+// of a PDF generated by Google Chrome.
+func pdfPageCount(data []byte) int {
+	return len(pdfPageRegexp.FindAllIndex(data, -1))
+}
+
+/*
+applyHeaderFooterVariants prints the page again for
+each kind of page with specific templates, and replaces
+the pages of the PDF file at given path with the
+corresponding pages of these variants.
+
+It returns the data of the resulting PDF file.
+*/
+func (p chromePrinter) applyHeaderFooterVariants(
+	ctx context.Context,
+	conn *rpcc.Conn,
+	fpath string,
+	data []byte,
+) ([]byte, error) {
+	const op string = "printer.chromePrinter.applyHeaderFooterVariants"
+	resolver := func() ([]byte, error) {
+		pageCount := pdfPageCount(data)
+		// "A" is the handle of the PDF file with
+		// the default templates.
+		handles := map[pageKind]string{}
+		inputs := []string{fmt.Sprintf("A=%s", fpath)}
+		var tmpFpaths []string
+		defer func() {
+			for _, tmpFpath := range tmpFpaths {
+				os.Remove(tmpFpath) // nolint: errcheck
+			}
+		}()
+		var pages []string
+		for pageNumber := 1; pageNumber <= pageCount; pageNumber++ {
+			kind := kindOfPage(pageNumber)
+			handle, ok := handles[kind]
+			if !ok {
+				handle = "A"
+				headerHTML, footerHTML := p.templates(kind)
+				if headerHTML != p.opts.HeaderHTML || footerHTML != p.opts.FooterHTML {
+					p.logger.DebugOpf(op, "printing the variant of page '%d'...", pageNumber)
+					variant, err := p.printToPDF(ctx, conn, headerHTML, footerHTML)
+					if err != nil {
+						return nil, err
+					}
+					variantFpath := filepath.Join(filepath.Dir(fpath), fmt.Sprintf("%s.pdf", xrand.Get()))
+					tmpFpaths = append(tmpFpaths, variantFpath)
+					if err := ioutil.WriteFile(variantFpath, variant, 0600); err != nil {
+						return nil, err
+					}
+					handle = string(rune('B' + len(inputs) - 1))
+					inputs = append(inputs, fmt.Sprintf("%s=%s", handle, variantFpath))
+				}
+				handles[kind] = handle
+			}
+			pages = append(pages, fmt.Sprintf("%s%d", handle, pageNumber))
+		}
+		if len(inputs) == 1 {
+			p.logger.DebugOp(op, "no variant to apply, moving on...")
+			return data, nil
+		}
+		resultFpath := filepath.Join(filepath.Dir(fpath), fmt.Sprintf("%s.pdf", xrand.Get()))
+		tmpFpaths = append(tmpFpaths, resultFpath)
+		var args []string
+		args = append(args, inputs...)
+		args = append(args, "cat")
+		args = append(args, pages...)
+		args = append(args, "output", resultFpath)
+		if err := xexec.Run(ctx, p.logger, "pdftk", args...); err != nil {
+			return nil, err
+		}
+		result, err := ioutil.ReadFile(resultFpath)
+		if err != nil {
+			return nil, err
+		}
+		if err := ioutil.WriteFile(fpath, result, 0600); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+	result, err := resolver()
+	if err != nil {
+		return nil, xerror.New(op, err)
+	}
+	return result, nil
+}
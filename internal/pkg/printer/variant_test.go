@@ -0,0 +1,59 @@
+package printer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thecodingmachine/gotenberg/internal/pkg/conf"
+	"github.com/thecodingmachine/gotenberg/test"
+)
+
+func TestKindOfPage(t *testing.T) {
+	assert.Equal(t, firstPage, kindOfPage(1))
+	assert.Equal(t, evenPage, kindOfPage(2))
+	assert.Equal(t, oddPage, kindOfPage(3))
+	assert.Equal(t, evenPage, kindOfPage(4))
+}
+
+func TestTemplates(t *testing.T) {
+	config := conf.DefaultConfig()
+	opts := DefaultChromePrinterOptions(config)
+	opts.HeaderHTML = "header"
+	opts.FooterHTML = "footer"
+	// no variants.
+	p := newChromePrinter(test.DebugLogger(), "", opts)
+	assert.Equal(t, false, opts.HeaderFooterVariants.any())
+	for _, kind := range []pageKind{firstPage, oddPage, evenPage} {
+		header, footer := p.templates(kind)
+		assert.Equal(t, "header", header)
+		assert.Equal(t, "footer", footer)
+	}
+	// odd and even variants.
+	opts.HeaderFooterVariants = HeaderFooterVariants{
+		OddHeaderHTML:  "odd header",
+		EvenFooterHTML: "even footer",
+	}
+	p = newChromePrinter(test.DebugLogger(), "", opts)
+	assert.Equal(t, true, opts.HeaderFooterVariants.any())
+	header, footer := p.templates(firstPage)
+	assert.Equal(t, "odd header", header)
+	assert.Equal(t, "footer", footer)
+	header, footer = p.templates(oddPage)
+	assert.Equal(t, "odd header", header)
+	assert.Equal(t, "footer", footer)
+	header, footer = p.templates(evenPage)
+	assert.Equal(t, "header", header)
+	assert.Equal(t, "even footer", footer)
+	// first page variant.
+	opts.HeaderFooterVariants.FirstHeaderHTML = "first header"
+	p = newChromePrinter(test.DebugLogger(), "", opts)
+	header, footer = p.templates(firstPage)
+	assert.Equal(t, "first header", header)
+	assert.Equal(t, "footer", footer)
+}
+
+func TestPDFPageCount(t *testing.T) {
+	data := []byte("<< /Type /Pages /Count 2 >> << /Type /Page >> <</Type/Page/Parent 1 0 R>>")
+	assert.Equal(t, 2, pdfPageCount(data))
+	assert.Equal(t, 0, pdfPageCount([]byte("foo")))
+}
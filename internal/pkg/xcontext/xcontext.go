@@ -25,7 +25,8 @@ in the given Context.
 If no error, returns the previous error.
 
 If context.DeadlineExceeded, wraps the previous
-error inside an xerror.Error with xerror.TimeoutCode.
+error inside an xerror.Error with xerror.TimeoutCode,
+unless the previous error already has this code.
 
 Otherwise wraps the previous error inside an
 xerror.Error.
@@ -45,6 +46,11 @@ func MustHandleError(ctx context.Context, previousErr error) error {
 	}
 	// context has timed out
 	if strings.Contains(err.Error(), context.DeadlineExceeded.Error()) {
+		// keep the (more relevant) message of
+		// the previous timeout error.
+		if xerror.Code(previousErr) == xerror.TimeoutCode {
+			return previousErr
+		}
 		return xerror.Timeout(op, "context has timed out", previousErr)
 	}
 	/*
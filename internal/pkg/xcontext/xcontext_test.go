@@ -32,6 +32,12 @@ func TestMustHandleError(t *testing.T) {
 	err = MustHandleError(ctx, previousErr)
 	xerr := test.AssertError(t, err)
 	assert.Equal(t, xerror.TimeoutCode, xerror.Code(xerr))
+	// context should timed out but previous
+	// error is already a timeout.
+	previousTimeoutErr := xerror.Timeout("foo", "previous timeout", previousErr)
+	err = MustHandleError(ctx, previousTimeoutErr)
+	assert.Equal(t, previousTimeoutErr, err)
+	assert.Equal(t, "previous timeout", xerror.Message(err))
 	// context should have an error different
 	// than context.DeadlineExceeded.
 	ctx, cancel = WithTimeout(logger, 5)
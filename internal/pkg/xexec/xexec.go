@@ -102,7 +102,15 @@ func Run(ctx context.Context, logger xlog.Logger, binary string, args ...string)
 // LogBeforeExecute logs a command before its execution.
 func LogBeforeExecute(logger xlog.Logger, cmd *exec.Cmd) {
 	const op string = "xexec.LogBeforeExecute"
-	logger.DebugOpf(op, "executing command: %s", strings.Join(cmd.Args, " "))
+	args := make([]string, len(cmd.Args))
+	copy(args, cmd.Args)
+	// do not log the passwords.
+	for i := 1; i < len(args); i++ {
+		if args[i-1] == "--password" {
+			args[i] = "***"
+		}
+	}
+	logger.DebugOpf(op, "executing command: %s", strings.Join(args, " "))
 }
 
 func pipe(logger xlog.Logger, cmd *exec.Cmd) error {
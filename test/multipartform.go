@@ -31,6 +31,26 @@ func HTMLMultipartForm(t *testing.T, formValues map[string]string) (*bytes.Buffe
 	return multipartForm(t, "html", formValues, fpaths)
 }
 
+/*
+InlineMultipartForm returns the body
+for a multipart/form-data request with all
+files under "testdata/inline" folder.
+*/
+func InlineMultipartForm(t *testing.T, formValues map[string]string) (*bytes.Buffer, string) {
+	fpaths := InlineFpaths(t)
+	return multipartForm(t, "html", formValues, fpaths)
+}
+
+/*
+TemplateMultipartForm returns the body
+for a multipart/form-data request with all
+files under "testdata/template" folder.
+*/
+func TemplateMultipartForm(t *testing.T, formValues map[string]string) (*bytes.Buffer, string) {
+	fpaths := TemplateFpaths(t)
+	return multipartForm(t, "template", formValues, fpaths)
+}
+
 /*
 URLMultipartForm returns the body
 for a multipart/form-data request with all
@@ -51,6 +71,17 @@ func MarkdownMultipartForm(t *testing.T, formValues map[string]string) (*bytes.B
 	return multipartForm(t, "markdown", formValues, fpaths)
 }
 
+/*
+MarkdownFilesMultipartForm returns the body
+for a multipart/form-data request with all
+files under "testdata/markdown" folder,
+except the index.html file.
+*/
+func MarkdownFilesMultipartForm(t *testing.T, formValues map[string]string) (*bytes.Buffer, string) {
+	fpaths := MarkdownFilesFpaths(t)
+	return multipartForm(t, "markdown", formValues, fpaths)
+}
+
 /*
 OfficeMultipartForm returns the body
 for a multipart/form-data request with all
@@ -61,6 +92,17 @@ func OfficeMultipartForm(t *testing.T, formValues map[string]string) (*bytes.Buf
 	return multipartForm(t, "office", formValues, fpaths)
 }
 
+/*
+ProtectedOfficeMultipartForm returns the body
+for a multipart/form-data request with the
+password protected file under "testdata/office"
+folder.
+*/
+func ProtectedOfficeMultipartForm(t *testing.T, formValues map[string]string) (*bytes.Buffer, string) {
+	fpaths := []string{ProtectedOfficeFpath(t)}
+	return multipartForm(t, "office", formValues, fpaths)
+}
+
 func multipartForm(
 	t *testing.T,
 	kind string,
@@ -88,3 +130,13 @@ func multipartForm(
 	}
 	return body, writer.FormDataContentType()
 }
+
+/*
+ArchiveMultipartForm returns the body
+for a multipart/form-data request with
+given archive under "testdata/archive" folder.
+*/
+func ArchiveMultipartForm(t *testing.T, filename string, formValues map[string]string) (*bytes.Buffer, string) {
+	fpaths := []string{fpath(t, "archive", filename)}
+	return multipartForm(t, "archive", formValues, fpaths)
+}
@@ -2,6 +2,9 @@ package test
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -43,6 +46,17 @@ func HTMLFpaths(t *testing.T) []string {
 	}
 }
 
+// InlineFpaths return the paths of all
+// files under "testdata/inline" folder.
+func InlineFpaths(t *testing.T) []string {
+	return []string{
+		fpath(t, "inline", "index.html"),
+		fpath(t, "inline", "header.html"),
+		fpath(t, "inline", "header.css"),
+		fpath(t, "inline", "img.gif"),
+	}
+}
+
 // URLFpaths return the paths of all
 // files under "testdata/url" folder.
 func URLFpaths(t *testing.T) []string {
@@ -52,8 +66,9 @@ func URLFpaths(t *testing.T) []string {
 	}
 }
 
-// MarkdownFpaths return the paths of all
-// files under "testdata/markdown" folder.
+// MarkdownFpaths return the paths of the shared
+// files under "testdata/markdown" folder. The
+// dedicated fixtures are loaded with MarkdownFpath.
 func MarkdownFpaths(t *testing.T) []string {
 	return []string{
 		fpath(t, "markdown", "index.html"),
@@ -68,6 +83,56 @@ func MarkdownFpaths(t *testing.T) []string {
 	}
 }
 
+// MarkdownFpath returns the path of given
+// file under "testdata/markdown" folder.
+func MarkdownFpath(t *testing.T, filename string) string {
+	return fpath(t, "markdown", filename)
+}
+
+// MarkdownFilesFpaths return the paths of the
+// files under "testdata/markdown" folder,
+// except the index.html file.
+func MarkdownFilesFpaths(t *testing.T) []string {
+	return MarkdownFpaths(t)[1:]
+}
+
+// JavaScriptFpaths return the paths of all
+// files under "testdata/javascript" folder.
+func JavaScriptFpaths(t *testing.T) []string {
+	return []string{
+		fpath(t, "javascript", "exception.html"),
+	}
+}
+
+// VariantFpaths return the paths of all
+// files under "testdata/variant" folder.
+func VariantFpaths(t *testing.T) []string {
+	return []string{
+		fpath(t, "variant", "header-first.html"),
+		fpath(t, "variant", "header-odd.html"),
+		fpath(t, "variant", "header-even.html"),
+		fpath(t, "variant", "footer-first.html"),
+		fpath(t, "variant", "footer-odd.html"),
+		fpath(t, "variant", "footer-even.html"),
+	}
+}
+
+// TemplateFpaths return the paths of all
+// files under "testdata/template" folder.
+func TemplateFpaths(t *testing.T) []string {
+	return []string{
+		fpath(t, "template", "index.html"),
+		fpath(t, "template", "header.html"),
+		fpath(t, "template", "data.json"),
+	}
+}
+
+// TemplateFpath returns the path of given
+// file under "testdata/template" folder.
+func TemplateFpath(t *testing.T, filename string) string {
+	return fpath(t, "template", filename)
+}
+
 // OfficeFpaths return the paths of all
 // files under "testdata/office" folder.
 func OfficeFpaths(t *testing.T) []string {
@@ -79,6 +144,65 @@ func OfficeFpaths(t *testing.T) []string {
 	}
 }
 
+// OfficeFormatsFpaths return the paths of
+// the files under "testdata/office" folder
+// for the other accepted formats, one per
+// format.
+func OfficeFormatsFpaths(t *testing.T) []string {
+	return []string{
+		fpath(t, "office", "document.csv"),
+		fpath(t, "office", "document.xml"),
+		fpath(t, "office", "document.fods"),
+		fpath(t, "office", "document.fodp"),
+		fpath(t, "office", "document.fodg"),
+		fpath(t, "office", "document.odg"),
+		fpath(t, "office", "document.vsdx"),
+		fpath(t, "office", "document.wpd"),
+		fpath(t, "office", "document.pub"),
+	}
+}
+
+// OfficeFpath returns the path of given
+// file under "testdata/office" folder.
+func OfficeFpath(t *testing.T, filename string) string {
+	return fpath(t, "office", filename)
+}
+
+// ProtectedOfficeFpath returns the path of
+// the password protected file under
+// "testdata/office" folder. Its password
+// is "foo".
+func ProtectedOfficeFpath(t *testing.T) string {
+	return fpath(t, "office", "protected.odt")
+}
+
+/*
+CopyFpaths copies given files into a new
+temporary directory, removed at the end of
+the test, and returns the paths of the copies.
+
+The printers which write the rendered HTML
+next to their input (e.g. the Markdown one)
+should use these copies, so that the test
+data stays untouched.
+*/
+func CopyFpaths(t *testing.T, fpaths ...string) []string {
+	dirPath, err := ioutil.TempDir("", "testdata")
+	require.Nil(t, err)
+	t.Cleanup(func() {
+		os.RemoveAll(dirPath) // nolint: errcheck
+	})
+	copies := make([]string, len(fpaths))
+	for i, fpath := range fpaths {
+		b, err := ioutil.ReadFile(fpath)
+		require.Nil(t, err)
+		copies[i] = filepath.Join(dirPath, filepath.Base(fpath))
+		err = ioutil.WriteFile(copies[i], b, 0600)
+		require.Nil(t, err)
+	}
+	return copies
+}
+
 func fpath(t *testing.T, kind, filename string) string {
 	require.NotEmpty(t, kind)
 	require.NotEmpty(t, filename)